@@ -0,0 +1,89 @@
+// Package buildconfig provides the helpers shared by the apptools builders
+// that declare per build configuration overlays, i.e. `Debug` and `Release`,
+// such as comparing the resolved dictionaries and naming the saved files.
+package buildconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/illyabusigin/apptools/filesystem"
+)
+
+// Difference describes a key whose value differs between build
+// configurations. Values contains the value for each configuration, keyed by
+// the configuration name; configurations without the key are omitted.
+type Difference struct {
+	Key    string
+	Values map[string]interface{}
+}
+
+// Differences returns the keys whose values differ between the resolved
+// dictionaries of the specified build configurations, sorted by key.
+func Differences(names []string, resolved map[string]map[string]interface{}) []Difference {
+	keys := map[string]bool{}
+	for _, name := range names {
+		for key := range resolved[name] {
+			keys[key] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	diffs := []Difference{}
+	for _, key := range sorted {
+		d := Difference{Key: key, Values: map[string]interface{}{}}
+
+		first, firstFound := resolved[names[0]][key]
+
+		differs := false
+		for _, name := range names {
+			value, found := resolved[name][key]
+			if found {
+				d.Values[name] = value
+			}
+
+			differs = differs || found != firstFound || !reflect.DeepEqual(first, value)
+		}
+
+		if differs {
+			diffs = append(diffs, d)
+		}
+	}
+
+	return diffs
+}
+
+// Path inserts the build configuration name before the extension of the
+// path, i.e. `Info.plist` becomes `Info-Debug.plist`.
+func Path(path, name string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + name + ext
+}
+
+// Save builds and writes one file per build configuration next to the
+// specified path within the provided file system, naming each file with
+// `Path`.
+func Save(fsys filesystem.FileSystem, path string, names []string, build func(name string) (string, error)) error {
+	for _, name := range names {
+		data, err := build(name)
+		if err != nil {
+			return fmt.Errorf("Unable to build %v: %w", name, err)
+		}
+
+		file := Path(path, name)
+		if err := fsys.WriteFile(file, []byte(data), 0644); err != nil {
+			return fmt.Errorf("Unable to write %v: %w", file, err)
+		}
+	}
+
+	return nil
+}
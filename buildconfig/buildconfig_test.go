@@ -0,0 +1,136 @@
+package buildconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestDifferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		resolved map[string]map[string]interface{}
+		want     []Difference
+	}{
+		{
+			name:  "Keys with different or missing values should be returned",
+			names: []string{"Debug", "Release"},
+			resolved: map[string]map[string]interface{}{
+				"Debug": {
+					"CFBundleName":         "Best App",
+					"aps-environment":      "development",
+					"UIFileSharingEnabled": true,
+				},
+				"Release": {
+					"CFBundleName":    "Best App",
+					"aps-environment": "production",
+				},
+			},
+			want: []Difference{
+				{Key: "UIFileSharingEnabled", Values: map[string]interface{}{"Debug": true}},
+				{Key: "aps-environment", Values: map[string]interface{}{"Debug": "development", "Release": "production"}},
+			},
+		},
+		{
+			name: "No configurations should not have any differences",
+			want: []Difference{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Differences(tt.names, tt.resolved))
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		configuration string
+		want          string
+	}{
+		{
+			name:          "The configuration should be inserted before the extension",
+			path:          "Info.plist",
+			configuration: "Debug",
+			want:          "Info-Debug.plist",
+		},
+		{
+			name:          "The folder should be preserved",
+			path:          filepath.Join("App", "App.entitlements"),
+			configuration: "Release",
+			want:          filepath.Join("App", "App-Release.entitlements"),
+		},
+		{
+			name:          "Paths without an extension should be suffixed",
+			path:          "Info",
+			configuration: "Debug",
+			want:          "Info-Debug",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Path(tt.path, tt.configuration))
+		})
+	}
+}
+
+func TestSave(t *testing.T) {
+	errBuild := errors.New("Build failed")
+
+	tests := []struct {
+		name    string
+		build   func(name string) (string, error)
+		wantErr error
+		want    map[string]string
+	}{
+		{
+			name: "Every configuration should be written next to the path",
+			build: func(name string) (string, error) {
+				return name, nil
+			},
+			want: map[string]string{
+				"App/Info-Debug.plist":   "Debug",
+				"App/Info-Release.plist": "Release",
+			},
+		},
+		{
+			name: "Build errors should be returned",
+			build: func(name string) (string, error) {
+				return "", errBuild
+			},
+			wantErr: errBuild,
+			want:    map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			assert.Nil(t, fsys.MkdirAll("App", os.ModePerm))
+
+			err := Save(fsys, filepath.Join("App", "Info.plist"), []string{"Debug", "Release"}, tt.build)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.Nil(t, err)
+			}
+
+			files := []string{}
+			for name, want := range tt.want {
+				files = append(files, name)
+
+				data, err := fsys.ReadFile(name)
+				assert.Nil(t, err)
+				assert.Equal(t, want, string(data))
+			}
+
+			assert.ElementsMatch(t, files, fsys.Files())
+		})
+	}
+}
@@ -7,9 +7,9 @@ type APS struct {
 }
 
 // Apply will apply the APS entitlements
-func (a *APS) Apply(e *Entitlements) {
+func (a *APS) Apply(data map[string]interface{}) {
 	if a.environment != "" {
-		e.data["aps-environment"] = a.environment
+		data["aps-environment"] = a.environment
 	}
 }
 
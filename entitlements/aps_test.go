@@ -22,10 +22,8 @@ func TestAPS_Apply(t *testing.T) {
 	aps := APS{}
 	aps.Development()
 
-	e := Entitlements{
-		data: map[string]interface{}{},
-	}
+	data := map[string]interface{}{}
 
-	aps.Apply(&e)
-	assert.Equal(t, "development", e.data["aps-environment"])
+	aps.Apply(data)
+	assert.Equal(t, "development", data["aps-environment"])
 }
@@ -0,0 +1,112 @@
+package entitlements
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+const (
+	// CodeInvalidAssociatedDomain is reported for malformed associated
+	// domains.
+	CodeInvalidAssociatedDomain = "invalid-associated-domain"
+
+	keyAssociatedDomains = "com.apple.developer.associated-domains"
+)
+
+var (
+	// ErrMissingServicePrefix is the error returned for associated domains
+	// without a known service prefix, i.e. `applinks:`.
+	ErrMissingServicePrefix = errors.New("Associated domains must start with a service prefix: applinks, webcredentials, activitycontinuation or appclips")
+
+	// ErrInvalidDomain is the error returned for associated domains that are
+	// not a fully qualified domain, optionally prefixed with a wildcard and
+	// followed by an alternate mode, i.e. `*.best.app?mode=developer`.
+	ErrInvalidDomain = errors.New("Associated domains must be a domain name without a scheme or path")
+
+	associatedDomainServices = map[string]bool{
+		"applinks":             true,
+		"webcredentials":       true,
+		"activitycontinuation": true,
+		"appclips":             true,
+	}
+
+	domainRegex = regexp.MustCompile(`^(\*\.)?[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*(\?mode=(developer|managed|developer\+managed))?$`)
+)
+
+// AssociatedDomains allows you to specify the domains associated with the
+// app for universal links, shared web credentials, Handoff and App Clips.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_associated-domains for more information.
+type AssociatedDomains struct {
+	domains []string
+}
+
+// Apply will apply the associated domains entitlements
+func (d *AssociatedDomains) Apply(data map[string]interface{}) {
+	if len(d.domains) > 0 {
+		data[keyAssociatedDomains] = d.domains
+	}
+}
+
+func (d *AssociatedDomains) add(service string, domains []string) {
+	for _, domain := range domains {
+		d.domains = appendUnique(d.domains, service+":"+domain)
+	}
+}
+
+// Add specifies associated domains including their service prefix, i.e.
+// `applinks:best.app`.
+func (d *AssociatedDomains) Add(entries ...string) {
+	d.domains = appendUnique(d.domains, entries...)
+}
+
+// AppLinks specifies the domains used for universal links, i.e.
+// `best.app`.
+func (d *AssociatedDomains) AppLinks(domains ...string) {
+	d.add("applinks", domains)
+}
+
+// WebCredentials specifies the domains used for shared web credentials.
+func (d *AssociatedDomains) WebCredentials(domains ...string) {
+	d.add("webcredentials", domains)
+}
+
+// ActivityContinuation specifies the domains used for Handoff.
+func (d *AssociatedDomains) ActivityContinuation(domains ...string) {
+	d.add("activitycontinuation", domains)
+}
+
+// AppClips specifies the domains used to launch App Clips.
+func (d *AssociatedDomains) AppClips(domains ...string) {
+	d.add("appclips", domains)
+}
+
+// Validate will validate the associated domains and return any errors found.
+func (d *AssociatedDomains) Validate() error {
+	return d.Report().Err()
+}
+
+// Report will validate the associated domains and return a report containing
+// every issue found.
+func (d *AssociatedDomains) Report() *validation.Report {
+	r := &validation.Report{}
+
+	for idx, entry := range d.domains {
+		path := validation.Index("", idx)
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || !associatedDomainServices[parts[0]] {
+			r.AddError(path, CodeInvalidAssociatedDomain, keyAssociatedDomains, fmt.Errorf("%w: %q", ErrMissingServicePrefix, entry))
+			continue
+		}
+
+		if !domainRegex.MatchString(parts[1]) {
+			r.AddError(path, CodeInvalidAssociatedDomain, keyAssociatedDomains, fmt.Errorf("%w: %q", ErrInvalidDomain, entry))
+		}
+	}
+
+	return r
+}
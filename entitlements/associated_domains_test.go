@@ -0,0 +1,45 @@
+package entitlements
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAssociatedDomains_Apply(t *testing.T) {
+	d := AssociatedDomains{}
+	d.AppLinks("best.app", "www.best.app")
+	d.WebCredentials("best.app")
+	d.ActivityContinuation("best.app")
+	d.AppClips("best.app")
+	d.AppLinks("best.app")
+
+	data := map[string]interface{}{}
+
+	d.Apply(data)
+	assert.Equal(t, []string{
+		"applinks:best.app",
+		"applinks:www.best.app",
+		"webcredentials:best.app",
+		"activitycontinuation:best.app",
+		"appclips:best.app",
+	}, data["com.apple.developer.associated-domains"])
+}
+
+func TestAssociatedDomains_Report(t *testing.T) {
+	d := AssociatedDomains{}
+	d.AppLinks("best.app", "*.best.app")
+	d.Add("webcredentials:best.app?mode=developer")
+	assert.Nil(t, d.Validate())
+
+	d.Add("best.app", "links:best.app", "applinks:https://best.app/path")
+
+	issues := d.Report().Errors()
+	assert.Len(t, issues, 3)
+	assert.Equal(t, "[3]", issues[0].Path)
+	assert.Equal(t, CodeInvalidAssociatedDomain, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], ErrMissingServicePrefix))
+	assert.True(t, errors.Is(issues[1], ErrMissingServicePrefix))
+	assert.True(t, errors.Is(issues[2], ErrInvalidDomain))
+}
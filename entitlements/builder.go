@@ -1,11 +1,33 @@
 package entitlements
 
 import (
-	"bytes"
-	"fmt"
+	"errors"
 	"io"
+	"regexp"
 
-	"howett.net/plist"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported by the entitlements builder.
+const (
+	CodeMissingProperty   = validation.CodeMissingProperty
+	CodeInvalidIdentifier = "invalid-identifier"
+)
+
+var (
+	// ErrMissingRequiredProperty is the error returned for missing properties
+	ErrMissingRequiredProperty = validation.ErrMissingRequiredProperty
+
+	// ErrNoEntitlements is the error returned when building entitlements
+	// without any keys.
+	ErrNoEntitlements = errors.New("No entitlements found")
+
+	// identifierComponent matches a reverse DNS component or a build setting
+	// reference, i.e. `best` or `$(PRODUCT_BUNDLE_IDENTIFIER)`.
+	identifierComponent = `([A-Za-z0-9\-]+|\$\([A-Za-z0-9_]+\))`
+
+	identifierRegex = regexp.MustCompile(`^` + identifierComponent + `(\.` + identifierComponent + `)*$`)
 )
 
 // Entitlements is a builder for assigning key-value pairs that grant
@@ -13,12 +35,31 @@ import (
 // See https://developer.apple.com/documentation/bundleresources/entitlements/ for more information.
 type Entitlements struct {
 	skipValidation bool
-
-	APS            *APS
-	DataProtection *DataProtection
-
-	data   map[string]interface{}
-	custom map[string]interface{}
+	platform       Platform
+	format         format.Format
+
+	APS                  *APS
+	DataProtection       *DataProtection
+	AppGroups            *AppGroups
+	KeychainAccessGroups *KeychainAccessGroups
+	ICloud               *ICloud
+	AssociatedDomains    *AssociatedDomains
+	SignInWithApple      *SignInWithApple
+	HealthKit            *HealthKit
+	HomeKit              *HomeKit
+	Siri                 *Siri
+	Wallet               *Wallet
+	InAppPayments        *InAppPayments
+	NetworkExtensions    *NetworkExtensions
+	PersonalVPN          *PersonalVPN
+	PushToTalk           *PushToTalk
+	AppSandbox           *AppSandbox
+	HardenedRuntime      *HardenedRuntime
+
+	configurations []configuration
+
+	custom  map[string]interface{}
+	removed map[string]bool
 }
 
 // SkipValidation will skip all validation when building entitlements
@@ -26,35 +67,114 @@ func (e *Entitlements) SkipValidation() {
 	e.skipValidation = true
 }
 
+// Platform specifies the targeted platform. When specified, entitlements that
+// are not supported on the platform, such as the App Sandbox on iOS, are
+// rejected during validation.
+func (e *Entitlements) Platform(platform Platform) {
+	e.platform = platform
+}
+
+// Format specifies the output format used when building the entitlements,
+// i.e. `format.PrettyXML` for XML indented the way Xcode writes it. Defaults
+// to single-line XML.
+func (e *Entitlements) Format(f format.Format) {
+	e.format = f
+}
+
 // Set will set an arbitrary key-value pair in your entitlements. Keys set in this
 // manner will override any keys set by any of the builder functions.
 func (e *Entitlements) Set(key string, value interface{}) {
+	delete(e.removed, key)
 	e.custom[key] = value
 }
 
+// Remove will remove keys from your entitlements, including keys set by any of
+// the builder functions. In a configuration overlay the keys are removed from
+// the base entitlements, i.e. to turn off an entitlement for `Debug`.
+func (e *Entitlements) Remove(keys ...string) {
+	if e.removed == nil {
+		e.removed = map[string]bool{}
+	}
+
+	for _, key := range keys {
+		delete(e.custom, key)
+		e.removed[key] = true
+	}
+}
+
+// Validate will validate the entitlements and return any errors found.
+func (e *Entitlements) Validate() error {
+	return e.Report().Err()
+}
+
+// Report will validate the entitlements and return a report containing every
+// issue found, including warnings.
+func (e *Entitlements) Report() *validation.Report {
+	r := &validation.Report{}
+
+	r.Merge("AppGroups", e.AppGroups.Report())
+	r.Merge("KeychainAccessGroups", e.KeychainAccessGroups.Report())
+	r.Merge("ICloud", e.ICloud.Report())
+	r.Merge("AssociatedDomains", e.AssociatedDomains.Report())
+	r.Merge("", e.platformReport(e.build()))
+
+	return r
+}
+
 // Build will build the Entitlements property list
 func (e *Entitlements) Build() (string, error) {
-	buf := bytes.Buffer{}
+	if !e.skipValidation {
+		if err := e.Validate(); err != nil {
+			return "", err
+		}
+	}
 
-	e.data = map[string]interface{}{}
+	data := e.build()
 
-	e.APS.Apply(e)
-	e.DataProtection.Apply(e)
+	if len(data) == 0 {
+		return "", ErrNoEntitlements
+	}
 
-	for key, val := range e.custom {
-		e.data[key] = val
+	out, err := format.Encode(data, e.format)
+	if err != nil {
+		return "", err
 	}
 
-	if len(e.data) == 0 {
-		return "", fmt.Errorf("No entitlements found")
+	return string(out), nil
+}
+
+// build computes the entitlements dictionary. Each section applies its keys
+// to the dictionary, leaving the builder unchanged.
+func (e *Entitlements) build() map[string]interface{} {
+	data := map[string]interface{}{}
+
+	e.APS.Apply(data)
+	e.DataProtection.Apply(data)
+	e.AppGroups.Apply(data)
+	e.KeychainAccessGroups.Apply(data)
+	e.ICloud.Apply(data)
+	e.AssociatedDomains.Apply(data)
+	e.SignInWithApple.Apply(data)
+	e.HealthKit.Apply(data)
+	e.HomeKit.Apply(data)
+	e.Siri.Apply(data)
+	e.Wallet.Apply(data)
+	e.InAppPayments.Apply(data)
+	e.NetworkExtensions.Apply(data)
+	e.PersonalVPN.Apply(data)
+	e.PushToTalk.Apply(data)
+	e.AppSandbox.Apply(data)
+	e.HardenedRuntime.Apply(data)
+
+	for key, val := range e.custom {
+		data[key] = val
 	}
 
-	encoder := plist.NewEncoder(&buf)
-	if err := encoder.Encode(e.data); err != nil {
-		return "", err
+	for key := range e.removed {
+		delete(data, key)
 	}
 
-	return buf.String(), nil
+	return data
 }
 
 // Write the entitlements to the specified io.Writer.
@@ -72,8 +192,23 @@ func (e *Entitlements) Write(w io.Writer) error {
 // New returns a new `Entitlements` builder.
 func New() *Entitlements {
 	return &Entitlements{
-		APS:            &APS{},
-		DataProtection: &DataProtection{},
-		custom:         map[string]interface{}{},
+		APS:                  &APS{},
+		DataProtection:       &DataProtection{},
+		AppGroups:            &AppGroups{},
+		KeychainAccessGroups: &KeychainAccessGroups{},
+		ICloud:               &ICloud{},
+		AssociatedDomains:    &AssociatedDomains{},
+		SignInWithApple:      &SignInWithApple{},
+		HealthKit:            &HealthKit{},
+		HomeKit:              &HomeKit{},
+		Siri:                 &Siri{},
+		Wallet:               &Wallet{},
+		InAppPayments:        &InAppPayments{},
+		NetworkExtensions:    &NetworkExtensions{},
+		PersonalVPN:          &PersonalVPN{},
+		PushToTalk:           &PushToTalk{},
+		AppSandbox:           &AppSandbox{},
+		HardenedRuntime:      &HardenedRuntime{},
+		custom:               map[string]interface{}{},
 	}
 }
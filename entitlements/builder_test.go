@@ -1,9 +1,11 @@
 package entitlements
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
+	"github.com/illyabusigin/apptools/format"
 	assert "github.com/stretchr/testify/require"
 )
 
@@ -13,6 +15,21 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, e)
 	assert.NotNil(t, e.APS)
 	assert.NotNil(t, e.DataProtection)
+	assert.NotNil(t, e.AppGroups)
+	assert.NotNil(t, e.KeychainAccessGroups)
+	assert.NotNil(t, e.ICloud)
+	assert.NotNil(t, e.AssociatedDomains)
+	assert.NotNil(t, e.SignInWithApple)
+	assert.NotNil(t, e.HealthKit)
+	assert.NotNil(t, e.HomeKit)
+	assert.NotNil(t, e.Siri)
+	assert.NotNil(t, e.Wallet)
+	assert.NotNil(t, e.InAppPayments)
+	assert.NotNil(t, e.NetworkExtensions)
+	assert.NotNil(t, e.PersonalVPN)
+	assert.NotNil(t, e.PushToTalk)
+	assert.NotNil(t, e.AppSandbox)
+	assert.NotNil(t, e.HardenedRuntime)
 }
 
 func TestEntitlements_SkipValidation(t *testing.T) {
@@ -54,6 +71,47 @@ func TestEntitlements_Build(t *testing.T) {
 <plist version="1.0"><dict><key>aps-environment</key><string>production</string></dict></plist>`,
 			wantErr: false,
 		},
+		{
+			name: "Build with app groups and associated domains should succeed",
+			fields: fields{
+				builder: func() *Entitlements {
+					e := New()
+					e.AppGroups.Add("group.com.best.app")
+					e.AssociatedDomains.AppLinks("best.app")
+					return e
+				},
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>com.apple.developer.associated-domains</key><array><string>applinks:best.app</string></array><key>com.apple.security.application-groups</key><array><string>group.com.best.app</string></array></dict></plist>`,
+			wantErr: false,
+		},
+		{
+			name: "Invalid app groups should return an error",
+			fields: fields{
+				builder: func() *Entitlements {
+					e := New()
+					e.AppGroups.Add("com.best.app")
+					return e
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid app groups should build when skipping validation",
+			fields: fields{
+				builder: func() *Entitlements {
+					e := New()
+					e.SkipValidation()
+					e.AppGroups.Add("com.best.app")
+					return e
+				},
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>com.apple.security.application-groups</key><array><string>com.best.app</string></array></dict></plist>`,
+			wantErr: false,
+		},
 		{
 			name: "Custom entitlements should build",
 			fields: fields{
@@ -107,6 +165,43 @@ func TestEntitlements_WriteError(t *testing.T) {
 
 	err := e.Write(&buf)
 	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrNoEntitlements))
+}
+
+func TestEntitlements_Report(t *testing.T) {
+	e := New()
+	e.AppGroups.Add("group.com.best.app", "best")
+	e.KeychainAccessGroups.Add("com.best.app")
+	e.ICloud.Containers("com.best.app")
+	e.AssociatedDomains.Add("best.app")
+
+	issues := e.Report().Errors()
+	assert.Len(t, issues, 4)
+	assert.Equal(t, "AppGroups[1]", issues[0].Path)
+	assert.Equal(t, "KeychainAccessGroups[0]", issues[1].Path)
+	assert.Equal(t, "ICloud.Containers[0]", issues[2].Path)
+	assert.Equal(t, "AssociatedDomains[0]", issues[3].Path)
+
+	err := e.Validate()
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidAppGroup))
+	assert.Contains(t, err.Error(), `AppGroups[1]: App group identifiers must start with 'group.'`)
+}
+
+func TestEntitlements_ReportLeavesBuilderUnchanged(t *testing.T) {
+	e := New()
+	e.AppGroups.Add("group.com.best.app")
+	e.Set("foo", "bar")
+
+	before, err := e.Build()
+	assert.Nil(t, err)
+
+	e.Report()
+	assert.Equal(t, map[string]interface{}{"foo": "bar"}, e.custom)
+
+	after, err := e.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, before, after)
 }
 
 func TestEntitlements_Set(t *testing.T) {
@@ -115,3 +210,27 @@ func TestEntitlements_Set(t *testing.T) {
 	e.Set("foo", "bar")
 	assert.Equal(t, "bar", e.custom["foo"])
 }
+
+func TestEntitlements_Format(t *testing.T) {
+	build := func() string {
+		e := New()
+		e.Format(format.PrettyXML)
+		e.APS.Production()
+		e.AppGroups.Add("group.com.best.app")
+		e.Set("com.best.custom", true)
+		e.Set("com.apple.developer.default-data-protection", "NSFileProtectionComplete")
+
+		out, err := e.Build()
+		assert.Nil(t, err)
+
+		return out
+	}
+
+	out := build()
+	assert.Contains(t, out, "<plist version=\"1.0\">\n<dict>\n\t<key>aps-environment</key>\n\t<string>production</string>\n")
+	assert.Contains(t, out, "\t<key>com.best.custom</key>\n\t<true/>\n</dict>\n</plist>\n")
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, out, build(), "Output should be byte-stable across runs")
+	}
+}
@@ -0,0 +1,168 @@
+package entitlements
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/illyabusigin/apptools/buildconfig"
+	"github.com/illyabusigin/apptools/filesystem"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// ErrUnknownConfiguration is the error returned for build configurations that
+// have not been declared.
+var ErrUnknownConfiguration = errors.New("Unknown configuration")
+
+// configuration is a named overlay applied on top of the base entitlements.
+type configuration struct {
+	name    string
+	overlay func(e *Entitlements)
+}
+
+// Difference describes an entitlement whose value differs between build
+// configurations.
+type Difference = buildconfig.Difference
+
+// Configuration declares a build configuration, i.e. `Debug` or `Release`,
+// as an overlay on top of the base entitlements. The overlay is applied to
+// empty entitlements and every key it sets replaces the corresponding key of
+// the base entitlements, i.e. `APS.Development()` for `Debug`, while the keys
+// it removes with `Remove` are removed from the base entitlements. Declaring a
+// configuration with the same name replaces the previous overlay.
+func (e *Entitlements) Configuration(name string, f func(e *Entitlements)) {
+	for idx, c := range e.configurations {
+		if c.name == name {
+			e.configurations[idx].overlay = f
+			return
+		}
+	}
+
+	e.configurations = append(e.configurations, configuration{name: name, overlay: f})
+}
+
+// Configurations returns the names of the declared build configurations in
+// the order they were declared.
+func (e *Entitlements) Configurations() []string {
+	names := make([]string, len(e.configurations))
+	for idx, c := range e.configurations {
+		names[idx] = c.name
+	}
+
+	return names
+}
+
+func (e *Entitlements) overlay(name string) (*Entitlements, error) {
+	for _, c := range e.configurations {
+		if c.name == name {
+			o := New()
+			o.platform = e.platform
+			c.overlay(o)
+			return o, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUnknownConfiguration, name)
+}
+
+// resolveConfiguration computes the entitlements dictionary of a build
+// configuration. The overlay replaces the keys it sets and removes the keys
+// it removes.
+func (e *Entitlements) resolveConfiguration(name string) (map[string]interface{}, error) {
+	o, err := e.overlay(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{}
+	for key, value := range e.build() {
+		data[key] = value
+	}
+
+	for key, value := range o.build() {
+		data[key] = value
+	}
+
+	for key := range o.removed {
+		delete(data, key)
+	}
+
+	return data, nil
+}
+
+// ConfigurationReport will validate the base entitlements and the overlay of
+// the specified build configuration.
+func (e *Entitlements) ConfigurationReport(name string) (*validation.Report, error) {
+	o, err := e.overlay(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r := e.Report()
+	r.Merge(fmt.Sprintf("Configurations[%v]", name), o.Report())
+
+	return r, nil
+}
+
+// BuildConfiguration will build the entitlements of the specified build
+// configuration.
+func (e *Entitlements) BuildConfiguration(name string) (string, error) {
+	if !e.skipValidation {
+		r, err := e.ConfigurationReport(name)
+		if err != nil {
+			return "", err
+		}
+
+		if err := r.Err(); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := e.resolveConfiguration(name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) == 0 {
+		return "", ErrNoEntitlements
+	}
+
+	out, err := format.Encode(data, e.format)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// SaveConfigurations builds and writes one entitlements file per build
+// configuration next to the specified path, inserting the configuration name
+// before the extension, i.e. `App.entitlements` is written as
+// `App-Debug.entitlements` and `App-Release.entitlements`.
+func (e *Entitlements) SaveConfigurations(path string) error {
+	return e.SaveConfigurationsFS(filesystem.OSFileSystem{}, path)
+}
+
+// SaveConfigurationsFS builds and writes one entitlements file per build
+// configuration within the provided file system, see `SaveConfigurations`.
+func (e *Entitlements) SaveConfigurationsFS(fsys filesystem.FileSystem, path string) error {
+	return buildconfig.Save(fsys, path, e.Configurations(), e.BuildConfiguration)
+}
+
+// ConfigurationDifferences returns the entitlements whose values differ
+// between the declared build configurations, sorted by key.
+func (e *Entitlements) ConfigurationDifferences() ([]Difference, error) {
+	names := e.Configurations()
+
+	resolved := map[string]map[string]interface{}{}
+	for _, name := range names {
+		data, err := e.resolveConfiguration(name)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[name] = data
+	}
+
+	return buildconfig.Differences(names, resolved), nil
+}
@@ -0,0 +1,254 @@
+package entitlements
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEntitlements_Configuration(t *testing.T) {
+	e := New()
+	e.APS.Production()
+	e.Configuration("Debug", func(e *Entitlements) {
+		e.APS.Development()
+	})
+	e.Configuration("Release", func(e *Entitlements) {})
+	e.Configuration("Staging", func(e *Entitlements) {})
+	e.Configuration("Debug", func(e *Entitlements) {
+		e.HomeKit.Enable()
+	})
+
+	assert.Equal(t, []string{"Debug", "Release", "Staging"}, e.Configurations())
+
+	out, err := e.BuildConfiguration("Debug")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>aps-environment</key><string>production</string>", "Redeclared configurations should replace the previous overlay")
+	assert.Contains(t, out, "<key>com.apple.developer.homekit</key><true/>")
+}
+
+func TestEntitlements_BuildConfiguration(t *testing.T) {
+	type fields struct {
+		entitlements func() *Entitlements
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		configuration string
+		want          string
+		wantErr       error
+	}{
+		{
+			name: "Overlay keys should replace the base keys",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.AppGroups.Add("group.com.best.app")
+					e.APS.Production()
+					e.Configuration("Debug", func(e *Entitlements) {
+						e.APS.Development()
+					})
+
+					return e
+				},
+			},
+			configuration: "Debug",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>aps-environment</key><string>development</string><key>com.apple.security.application-groups</key><array><string>group.com.best.app</string></array></dict></plist>`,
+		},
+		{
+			name: "Empty overlays should inherit the base keys",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.APS.Production()
+					e.Configuration("Release", func(e *Entitlements) {})
+
+					return e
+				},
+			},
+			configuration: "Release",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>aps-environment</key><string>production</string></dict></plist>`,
+		},
+		{
+			name: "Removed keys should be removed from the base keys",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.APS.Production()
+					e.HealthKit.Enable()
+					e.Set("get-task-allow", true)
+					e.Configuration("Release", func(e *Entitlements) {
+						e.Remove("com.apple.developer.healthkit", "get-task-allow")
+					})
+
+					return e
+				},
+			},
+			configuration: "Release",
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>aps-environment</key><string>production</string></dict></plist>`,
+		},
+		{
+			name: "Undeclared configurations should fail",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.APS.Production()
+					e.Configuration("Debug", func(e *Entitlements) {})
+
+					return e
+				},
+			},
+			configuration: "Profile",
+			wantErr:       ErrUnknownConfiguration,
+		},
+		{
+			name: "Configurations without any keys should fail",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.Configuration("Debug", func(e *Entitlements) {})
+
+					return e
+				},
+			},
+			configuration: "Debug",
+			wantErr:       ErrNoEntitlements,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fields.entitlements().BuildConfiguration(tt.configuration)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEntitlements_ConfigurationReport(t *testing.T) {
+	e := New()
+	e.Platform(PlatformIOS)
+	e.APS.Production()
+	e.Configuration("Debug", func(e *Entitlements) {
+		e.AppGroups.Add("com.best.app")
+		e.HardenedRuntime.AllowJIT()
+	})
+	e.Configuration("Release", func(e *Entitlements) {})
+
+	r, err := e.ConfigurationReport("Debug")
+	assert.Nil(t, err)
+
+	issues := r.Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "Configurations[Debug].AppGroups[0]", issues[0].Path)
+	assert.Equal(t, "Configurations[Debug].HardenedRuntime", issues[1].Path)
+
+	_, err = e.BuildConfiguration("Debug")
+	assert.True(t, errors.Is(err, ErrInvalidAppGroup))
+
+	_, err = e.BuildConfiguration("Release")
+	assert.Nil(t, err)
+}
+
+func TestEntitlements_ConfigurationDifferences(t *testing.T) {
+	type fields struct {
+		entitlements func() *Entitlements
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []Difference
+	}{
+		{
+			name: "Keys set or removed by a single configuration should differ",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					e := New()
+					e.APS.Production()
+					e.HomeKit.Enable()
+					e.Configuration("Debug", func(e *Entitlements) {
+						e.APS.Development()
+						e.Set("get-task-allow", true)
+					})
+					e.Configuration("Release", func(e *Entitlements) {
+						e.Remove("com.apple.developer.homekit")
+					})
+
+					return e
+				},
+			},
+			want: []Difference{
+				{Key: "aps-environment", Values: map[string]interface{}{"Debug": "development", "Release": "production"}},
+				{Key: "com.apple.developer.homekit", Values: map[string]interface{}{"Debug": true}},
+				{Key: "get-task-allow", Values: map[string]interface{}{"Debug": true}},
+			},
+		},
+		{
+			name: "Entitlements without configurations should not have any differences",
+			fields: fields{
+				entitlements: func() *Entitlements {
+					return New()
+				},
+			},
+			want: []Difference{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := tt.fields.entitlements().ConfigurationDifferences()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, diffs)
+		})
+	}
+}
+
+func TestEntitlements_Remove(t *testing.T) {
+	e := New()
+	e.APS.Production()
+	e.Set("get-task-allow", true)
+	e.Remove("aps-environment", "get-task-allow")
+
+	_, err := e.Build()
+	assert.True(t, errors.Is(err, ErrNoEntitlements), "Removed keys should not be built")
+
+	e.Set("get-task-allow", true)
+
+	out, err := e.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>get-task-allow</key><true/>", "Setting a removed key should restore it")
+}
+
+func TestEntitlements_SaveConfigurationsFS(t *testing.T) {
+	e := New()
+	e.APS.Production()
+	e.Configuration("Debug", func(e *Entitlements) {
+		e.APS.Development()
+	})
+	e.Configuration("Release", func(e *Entitlements) {})
+
+	fsys := filesystem.NewMemoryFileSystem()
+	assert.Nil(t, fsys.MkdirAll("App", os.ModePerm))
+	assert.Nil(t, e.SaveConfigurationsFS(fsys, "App/App.entitlements"))
+	assert.Equal(t, []string{"App/App-Debug.entitlements", "App/App-Release.entitlements"}, fsys.Files())
+
+	data, err := fsys.ReadFile("App/App-Debug.entitlements")
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "development")
+
+	e.Configuration("Release", func(e *Entitlements) {
+		e.AppGroups.Add("best")
+	})
+	assert.True(t, errors.Is(e.SaveConfigurationsFS(fsys, "App/App.entitlements"), ErrInvalidAppGroup))
+}
@@ -8,9 +8,9 @@ type DataProtection struct {
 }
 
 // Apply will apply the data protection entitlements
-func (p *DataProtection) Apply(e *Entitlements) {
+func (p *DataProtection) Apply(data map[string]interface{}) {
 	if p.value != "" {
-		e.data["com.apple.developer.default-data-protection"] = p.value
+		data["com.apple.developer.default-data-protection"] = p.value
 	}
 }
 
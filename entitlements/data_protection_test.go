@@ -34,10 +34,8 @@ func TestDataProtection_Apply(t *testing.T) {
 	dataProtection := DataProtection{}
 	dataProtection.None()
 
-	e := Entitlements{
-		data: map[string]interface{}{},
-	}
+	data := map[string]interface{}{}
 
-	dataProtection.Apply(&e)
-	assert.Equal(t, "NSFileProtectionNone", e.data["com.apple.developer.default-data-protection"])
+	dataProtection.Apply(data)
+	assert.Equal(t, "NSFileProtectionNone", data["com.apple.developer.default-data-protection"])
 }
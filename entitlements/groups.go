@@ -0,0 +1,129 @@
+package entitlements
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+const (
+	appGroupPrefix      = "group."
+	appIdentifierPrefix = "$(AppIdentifierPrefix)"
+)
+
+var (
+	// ErrInvalidAppGroup is the error returned for app group identifiers that
+	// are not of the form `group.com.best.app`.
+	ErrInvalidAppGroup = errors.New("App group identifiers must start with 'group.' followed by a reverse DNS identifier")
+
+	// ErrInvalidKeychainGroup is the error returned for keychain access
+	// groups that are not of the form `$(AppIdentifierPrefix)com.best.app`.
+	ErrInvalidKeychainGroup = errors.New("Keychain access groups must start with '$(AppIdentifierPrefix)' followed by a reverse DNS identifier")
+)
+
+// AppGroups allows you to specify the app groups that share containers with
+// the app.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_application-groups for more information.
+type AppGroups struct {
+	groups []string
+}
+
+// Apply will apply the app groups entitlements
+func (g *AppGroups) Apply(data map[string]interface{}) {
+	if len(g.groups) > 0 {
+		data["com.apple.security.application-groups"] = g.groups
+	}
+}
+
+// Add specifies app group identifiers, i.e. `group.com.best.app`.
+func (g *AppGroups) Add(groups ...string) {
+	g.groups = appendUnique(g.groups, groups...)
+}
+
+// Validate will validate the app groups and return any errors found.
+func (g *AppGroups) Validate() error {
+	return g.Report().Err()
+}
+
+// Report will validate the app groups and return a report containing every
+// issue found.
+func (g *AppGroups) Report() *validation.Report {
+	r := &validation.Report{}
+
+	for idx, group := range g.groups {
+		if !validPrefixedIdentifier(group, appGroupPrefix) {
+			r.AddError(validation.Index("", idx), CodeInvalidIdentifier, "com.apple.security.application-groups",
+				fmt.Errorf("%w: %q", ErrInvalidAppGroup, group))
+		}
+	}
+
+	return r
+}
+
+// KeychainAccessGroups allows you to specify the keychain groups shared with
+// other apps from the same team.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/keychain-access-groups for more information.
+type KeychainAccessGroups struct {
+	groups []string
+}
+
+// Apply will apply the keychain access groups entitlements
+func (g *KeychainAccessGroups) Apply(data map[string]interface{}) {
+	if len(g.groups) > 0 {
+		data["keychain-access-groups"] = g.groups
+	}
+}
+
+// Add specifies keychain access groups, i.e.
+// `$(AppIdentifierPrefix)com.best.app`.
+func (g *KeychainAccessGroups) Add(groups ...string) {
+	g.groups = appendUnique(g.groups, groups...)
+}
+
+// Validate will validate the keychain access groups and return any errors
+// found.
+func (g *KeychainAccessGroups) Validate() error {
+	return g.Report().Err()
+}
+
+// Report will validate the keychain access groups and return a report
+// containing every issue found.
+func (g *KeychainAccessGroups) Report() *validation.Report {
+	r := &validation.Report{}
+
+	for idx, group := range g.groups {
+		if !validPrefixedIdentifier(group, appIdentifierPrefix) {
+			r.AddError(validation.Index("", idx), CodeInvalidIdentifier, "keychain-access-groups",
+				fmt.Errorf("%w: %q", ErrInvalidKeychainGroup, group))
+		}
+	}
+
+	return r
+}
+
+// validPrefixedIdentifier returns true if the value is the prefix followed by
+// a reverse DNS identifier.
+func validPrefixedIdentifier(value, prefix string) bool {
+	return strings.HasPrefix(value, prefix) && identifierRegex.MatchString(strings.TrimPrefix(value, prefix))
+}
+
+// appendUnique appends the values that are not already present.
+func appendUnique(values []string, add ...string) []string {
+	for _, v := range add {
+		found := false
+		for _, existing := range values {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
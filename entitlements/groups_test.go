@@ -0,0 +1,64 @@
+package entitlements
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAppGroups_Add(t *testing.T) {
+	g := AppGroups{}
+	g.Add("group.com.best.app", "group.com.best.shared")
+	g.Add("group.com.best.app")
+	assert.Equal(t, []string{"group.com.best.app", "group.com.best.shared"}, g.groups)
+}
+
+func TestAppGroups_Apply(t *testing.T) {
+	data := map[string]interface{}{}
+
+	g := AppGroups{}
+	g.Apply(data)
+	assert.Empty(t, data)
+
+	g.Add("group.com.best.app")
+	g.Apply(data)
+	assert.Equal(t, []string{"group.com.best.app"}, data["com.apple.security.application-groups"])
+}
+
+func TestKeychainAccessGroups_Apply(t *testing.T) {
+	g := KeychainAccessGroups{}
+	g.Add("$(AppIdentifierPrefix)com.best.app")
+
+	data := map[string]interface{}{}
+
+	g.Apply(data)
+	assert.Equal(t, []string{"$(AppIdentifierPrefix)com.best.app"}, data["keychain-access-groups"])
+}
+
+func TestAppGroups_Report(t *testing.T) {
+	g := AppGroups{}
+	g.Add("group.com.best.app", "group.$(PRODUCT_BUNDLE_IDENTIFIER)")
+	assert.Nil(t, g.Validate())
+
+	g.Add("com.best.app", "group.")
+
+	issues := g.Report().Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "[2]", issues[0].Path)
+	assert.Equal(t, CodeInvalidIdentifier, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], ErrInvalidAppGroup))
+	assert.Equal(t, "[3]", issues[1].Path)
+}
+
+func TestKeychainAccessGroups_Report(t *testing.T) {
+	g := KeychainAccessGroups{}
+	g.Add("$(AppIdentifierPrefix)com.best.app")
+	assert.Nil(t, g.Validate())
+
+	g.Add("com.best.app")
+
+	err := g.Validate()
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidKeychainGroup))
+}
@@ -0,0 +1,120 @@
+package entitlements
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+const iCloudContainerPrefix = "iCloud."
+
+// ErrInvalidICloudContainer is the error returned for iCloud container
+// identifiers that are not of the form `iCloud.com.best.app`.
+var ErrInvalidICloudContainer = errors.New("iCloud container identifiers must start with 'iCloud.' followed by a reverse DNS identifier")
+
+// ICloud allows you to specify the iCloud containers and services the app
+// uses.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_icloud-services for more information.
+type ICloud struct {
+	containers           []string
+	ubiquityContainers   []string
+	services             []string
+	keyValueStore        string
+	containerEnvironment string
+}
+
+// Apply will apply the iCloud entitlements
+func (c *ICloud) Apply(data map[string]interface{}) {
+	if len(c.containers) > 0 {
+		data["com.apple.developer.icloud-container-identifiers"] = c.containers
+	}
+
+	if len(c.ubiquityContainers) > 0 {
+		data["com.apple.developer.ubiquity-container-identifiers"] = c.ubiquityContainers
+	}
+
+	if len(c.services) > 0 {
+		data["com.apple.developer.icloud-services"] = c.services
+	}
+
+	if c.keyValueStore != "" {
+		data["com.apple.developer.ubiquity-kvstore-identifier"] = c.keyValueStore
+	}
+
+	if c.containerEnvironment != "" {
+		data["com.apple.developer.icloud-container-environment"] = c.containerEnvironment
+	}
+}
+
+// Containers specifies the iCloud container identifiers, i.e.
+// `iCloud.com.best.app`.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_icloud-container-identifiers for more information.
+func (c *ICloud) Containers(ids ...string) {
+	c.containers = appendUnique(c.containers, ids...)
+}
+
+// UbiquityContainers specifies the iCloud Drive container identifiers.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_ubiquity-container-identifiers for more information.
+func (c *ICloud) UbiquityContainers(ids ...string) {
+	c.ubiquityContainers = appendUnique(c.ubiquityContainers, ids...)
+}
+
+// CloudKit specifies the app uses CloudKit.
+func (c *ICloud) CloudKit() {
+	c.services = appendUnique(c.services, "CloudKit")
+}
+
+// CloudDocuments specifies the app uses iCloud Documents.
+func (c *ICloud) CloudDocuments() {
+	c.services = appendUnique(c.services, "CloudDocuments")
+}
+
+// KeyValueStore specifies the iCloud key-value store identifier, typically
+// `$(TeamIdentifierPrefix)$(CFBundleIdentifier)`.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_ubiquity-kvstore-identifier for more information.
+func (c *ICloud) KeyValueStore(id string) {
+	c.keyValueStore = id
+}
+
+// Development specifies the development iCloud container environment.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_icloud-container-environment for more information.
+func (c *ICloud) Development() {
+	c.containerEnvironment = "Development"
+}
+
+// Production specifies the production iCloud container environment.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_icloud-container-environment for more information.
+func (c *ICloud) Production() {
+	c.containerEnvironment = "Production"
+}
+
+// Validate will validate the iCloud entitlements and return any errors found.
+func (c *ICloud) Validate() error {
+	return c.Report().Err()
+}
+
+// Report will validate the iCloud entitlements and return a report containing
+// every issue found.
+func (c *ICloud) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if len(c.services) > 0 && len(c.containers) == 0 {
+		r.AddError("Containers", CodeMissingProperty, "com.apple.developer.icloud-container-identifiers",
+			fmt.Errorf("%w: iCloud services require at least one container", ErrMissingRequiredProperty))
+	}
+
+	containersReport(r, "Containers", "com.apple.developer.icloud-container-identifiers", c.containers)
+	containersReport(r, "UbiquityContainers", "com.apple.developer.ubiquity-container-identifiers", c.ubiquityContainers)
+
+	return r
+}
+
+func containersReport(r *validation.Report, path, key string, containers []string) {
+	for idx, container := range containers {
+		if !validPrefixedIdentifier(container, iCloudContainerPrefix) {
+			r.AddError(validation.Index(path, idx), CodeInvalidIdentifier, key,
+				fmt.Errorf("%w: %q", ErrInvalidICloudContainer, container))
+		}
+	}
+}
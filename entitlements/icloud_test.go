@@ -0,0 +1,57 @@
+package entitlements
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/illyabusigin/apptools/validation"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestICloud_Environment(t *testing.T) {
+	c := ICloud{}
+	c.Development()
+	assert.Equal(t, "Development", c.containerEnvironment)
+
+	c.Production()
+	assert.Equal(t, "Production", c.containerEnvironment)
+}
+
+func TestICloud_Apply(t *testing.T) {
+	c := ICloud{}
+	c.Containers("iCloud.com.best.app")
+	c.UbiquityContainers("iCloud.com.best.app")
+	c.CloudKit()
+	c.CloudDocuments()
+	c.CloudKit()
+	c.KeyValueStore("$(TeamIdentifierPrefix)$(CFBundleIdentifier)")
+	c.Production()
+
+	data := map[string]interface{}{}
+
+	c.Apply(data)
+	assert.Equal(t, []string{"iCloud.com.best.app"}, data["com.apple.developer.icloud-container-identifiers"])
+	assert.Equal(t, []string{"iCloud.com.best.app"}, data["com.apple.developer.ubiquity-container-identifiers"])
+	assert.Equal(t, []string{"CloudKit", "CloudDocuments"}, data["com.apple.developer.icloud-services"])
+	assert.Equal(t, "$(TeamIdentifierPrefix)$(CFBundleIdentifier)", data["com.apple.developer.ubiquity-kvstore-identifier"])
+	assert.Equal(t, "Production", data["com.apple.developer.icloud-container-environment"])
+}
+
+func TestICloud_Report(t *testing.T) {
+	c := ICloud{}
+	c.Containers("iCloud.$(CFBundleIdentifier)")
+	c.CloudKit()
+	assert.Nil(t, c.Validate())
+
+	c = ICloud{}
+	c.CloudKit()
+	c.UbiquityContainers("com.best.app")
+
+	issues := c.Report().Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "Containers", issues[0].Path)
+	assert.True(t, errors.Is(issues[0], ErrMissingRequiredProperty))
+	assert.True(t, errors.Is(issues[0], validation.ErrMissingRequiredProperty))
+	assert.Equal(t, "UbiquityContainers[0]", issues[1].Path)
+	assert.True(t, errors.Is(issues[1], ErrInvalidICloudContainer))
+}
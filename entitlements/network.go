@@ -0,0 +1,66 @@
+package entitlements
+
+// NetworkExtensions allows you to specify the network extension providers the
+// app implements.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_networking_networkextension for more information.
+type NetworkExtensions struct {
+	providers []string
+}
+
+// Apply will apply the network extensions entitlements
+func (n *NetworkExtensions) Apply(data map[string]interface{}) {
+	if len(n.providers) > 0 {
+		data["com.apple.developer.networking.networkextension"] = n.providers
+	}
+}
+
+// PacketTunnelProvider specifies the app implements a packet tunnel provider.
+func (n *NetworkExtensions) PacketTunnelProvider() {
+	n.providers = appendUnique(n.providers, "packet-tunnel-provider")
+}
+
+// AppProxyProvider specifies the app implements an app proxy provider.
+func (n *NetworkExtensions) AppProxyProvider() {
+	n.providers = appendUnique(n.providers, "app-proxy-provider")
+}
+
+// ContentFilterProvider specifies the app implements a content filter
+// provider.
+func (n *NetworkExtensions) ContentFilterProvider() {
+	n.providers = appendUnique(n.providers, "content-filter-provider")
+}
+
+// DNSProxy specifies the app implements a DNS proxy provider.
+func (n *NetworkExtensions) DNSProxy() {
+	n.providers = appendUnique(n.providers, "dns-proxy")
+}
+
+// DNSSettings specifies the app configures DNS settings.
+func (n *NetworkExtensions) DNSSettings() {
+	n.providers = appendUnique(n.providers, "dns-settings")
+}
+
+// AppPushProvider specifies the app implements a local push connectivity
+// provider.
+func (n *NetworkExtensions) AppPushProvider() {
+	n.providers = appendUnique(n.providers, "app-push-provider")
+}
+
+// PersonalVPN allows you to enable the creation and control of a custom VPN
+// configuration.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_networking_vpn_api for more information.
+type PersonalVPN struct {
+	enabled bool
+}
+
+// Apply will apply the personal VPN entitlements
+func (v *PersonalVPN) Apply(data map[string]interface{}) {
+	if v.enabled {
+		data["com.apple.developer.networking.vpn.api"] = []string{"allow-vpn"}
+	}
+}
+
+// Enable enables the personal VPN API.
+func (v *PersonalVPN) Enable() {
+	v.enabled = true
+}
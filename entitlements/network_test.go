@@ -0,0 +1,40 @@
+package entitlements
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestNetworkExtensions_Apply(t *testing.T) {
+	n := NetworkExtensions{}
+	n.PacketTunnelProvider()
+	n.AppProxyProvider()
+	n.ContentFilterProvider()
+	n.DNSProxy()
+	n.DNSSettings()
+	n.AppPushProvider()
+	n.DNSProxy()
+
+	data := map[string]interface{}{}
+
+	n.Apply(data)
+	assert.Equal(t, []string{
+		"packet-tunnel-provider",
+		"app-proxy-provider",
+		"content-filter-provider",
+		"dns-proxy",
+		"dns-settings",
+		"app-push-provider",
+	}, data["com.apple.developer.networking.networkextension"])
+}
+
+func TestPersonalVPN_Apply(t *testing.T) {
+	v := PersonalVPN{}
+	v.Enable()
+
+	data := map[string]interface{}{}
+
+	v.Apply(data)
+	assert.Equal(t, []string{"allow-vpn"}, data["com.apple.developer.networking.vpn.api"])
+}
@@ -0,0 +1,74 @@
+package entitlements
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Platform represents the targeted platform
+type Platform string
+
+const (
+	// PlatformIOS is for validating the entitlements against the iOS platform
+	PlatformIOS Platform = "ios"
+
+	// PlatformMac is for validating the entitlements against the Mac/OSX
+	// platform
+	PlatformMac Platform = "mac"
+
+	// PlatformWatch is for validating the entitlements against the watchOS
+	// platform
+	PlatformWatch Platform = "watchos"
+
+	// PlatformTV is for validating the entitlements against the tvOS platform
+	PlatformTV Platform = "tvos"
+
+	// PlatformVision is for validating the entitlements against the visionOS
+	// platform
+	PlatformVision Platform = "visionos"
+)
+
+// CodeForbiddenProperty is reported for entitlements that are not supported
+// on the targeted platform.
+const CodeForbiddenProperty = "forbidden-property"
+
+// ErrForbiddenProperty is the error returned for entitlements that are not
+// supported on the targeted platform
+var ErrForbiddenProperty = errors.New("Property is not supported")
+
+// platformReport reports the entitlements that are not supported on the
+// targeted platform.
+func (e *Entitlements) platformReport(data map[string]interface{}) *validation.Report {
+	r := &validation.Report{}
+
+	if e.platform == "" || e.platform == PlatformMac {
+		return r
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !MacOnly(key) {
+			continue
+		}
+
+		path := "AppSandbox"
+		if _, found := e.custom[key]; found {
+			path = ""
+		} else if hardenedRuntimeKeys[key] {
+			path = "HardenedRuntime"
+		}
+
+		r.AddError(path, CodeForbiddenProperty, key, fmt.Errorf("%w on %v", ErrForbiddenProperty, e.platform))
+	}
+
+	return r
+}
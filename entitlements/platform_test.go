@@ -0,0 +1,46 @@
+package entitlements
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEntitlements_Platform(t *testing.T) {
+	e := New()
+	e.Platform(PlatformMac)
+	assert.Equal(t, PlatformMac, e.platform)
+}
+
+func TestEntitlements_PlatformReport(t *testing.T) {
+	e := New()
+	e.AppGroups.Add("group.com.best.app")
+	e.AppSandbox.NetworkClient()
+	e.HardenedRuntime.AllowJIT()
+	e.Set("com.apple.security.files.bookmarks.app-scope", true)
+
+	assert.Nil(t, e.Validate())
+
+	e.Platform(PlatformMac)
+	assert.Nil(t, e.Validate())
+
+	e.Platform(PlatformIOS)
+
+	issues := e.Report().Errors()
+	assert.Len(t, issues, 4)
+	assert.Equal(t, "AppSandbox", issues[0].Path)
+	assert.Equal(t, "com.apple.security.app-sandbox", issues[0].Key)
+	assert.Equal(t, CodeForbiddenProperty, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], ErrForbiddenProperty))
+	assert.Equal(t, "HardenedRuntime", issues[1].Path)
+	assert.Equal(t, "com.apple.security.cs.allow-jit", issues[1].Key)
+	assert.Equal(t, "", issues[2].Path)
+	assert.Equal(t, "com.apple.security.files.bookmarks.app-scope", issues[2].Key)
+	assert.Equal(t, "AppSandbox", issues[3].Path)
+	assert.Equal(t, "com.apple.security.network.client", issues[3].Key)
+	assert.Contains(t, issues[0].Error(), "Property is not supported on ios")
+
+	_, err := e.Build()
+	assert.NotNil(t, err)
+}
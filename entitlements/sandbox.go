@@ -0,0 +1,228 @@
+package entitlements
+
+// FileAccess describes the level of access granted to a location in the file
+// system.
+type FileAccess string
+
+const (
+	// FileAccessReadOnly grants read-only access.
+	FileAccessReadOnly FileAccess = "read-only"
+
+	// FileAccessReadWrite grants read/write access.
+	FileAccessReadWrite FileAccess = "read-write"
+)
+
+// sandboxKeys are the App Sandbox entitlements.
+// See https://developer.apple.com/documentation/security/app_sandbox for more information.
+var sandboxKeys = map[string]bool{
+	"com.apple.security.app-sandbox":                                             true,
+	"com.apple.security.inherit":                                                 true,
+	"com.apple.security.network.client":                                          true,
+	"com.apple.security.network.server":                                          true,
+	"com.apple.security.device.camera":                                           true,
+	"com.apple.security.device.microphone":                                       true,
+	"com.apple.security.device.audio-input":                                      true,
+	"com.apple.security.device.usb":                                              true,
+	"com.apple.security.device.bluetooth":                                        true,
+	"com.apple.security.device.serial":                                           true,
+	"com.apple.security.print":                                                   true,
+	"com.apple.security.personal-information.addressbook":                        true,
+	"com.apple.security.personal-information.calendars":                          true,
+	"com.apple.security.personal-information.location":                           true,
+	"com.apple.security.personal-information.photos-library":                     true,
+	"com.apple.security.files.user-selected.read-only":                           true,
+	"com.apple.security.files.user-selected.read-write":                          true,
+	"com.apple.security.files.user-selected.executable":                          true,
+	"com.apple.security.files.downloads.read-only":                               true,
+	"com.apple.security.files.downloads.read-write":                              true,
+	"com.apple.security.assets.pictures.read-only":                               true,
+	"com.apple.security.assets.pictures.read-write":                              true,
+	"com.apple.security.assets.music.read-only":                                  true,
+	"com.apple.security.assets.music.read-write":                                 true,
+	"com.apple.security.assets.movies.read-only":                                 true,
+	"com.apple.security.assets.movies.read-write":                                true,
+	"com.apple.security.files.bookmarks.app-scope":                               true,
+	"com.apple.security.files.bookmarks.document-scope":                          true,
+	"com.apple.security.automation.apple-events":                                 true,
+	"com.apple.security.scripting-targets":                                       true,
+	"com.apple.security.temporary-exception.apple-events":                        true,
+	"com.apple.security.temporary-exception.files.absolute-path.read-only":       true,
+	"com.apple.security.temporary-exception.files.absolute-path.read-write":      true,
+	"com.apple.security.temporary-exception.files.home-relative-path.read-only":  true,
+	"com.apple.security.temporary-exception.files.home-relative-path.read-write": true,
+	"com.apple.security.temporary-exception.mach-lookup.global-name":             true,
+}
+
+// hardenedRuntimeKeys are the Hardened Runtime entitlements.
+// See https://developer.apple.com/documentation/security/hardened_runtime for more information.
+var hardenedRuntimeKeys = map[string]bool{
+	"com.apple.security.cs.allow-jit":                          true,
+	"com.apple.security.cs.allow-unsigned-executable-memory":   true,
+	"com.apple.security.cs.allow-dyld-environment-variables":   true,
+	"com.apple.security.cs.disable-library-validation":         true,
+	"com.apple.security.cs.disable-executable-page-protection": true,
+	"com.apple.security.cs.debugger":                           true,
+}
+
+// MacOnly returns true if the entitlement is an App Sandbox or Hardened
+// Runtime entitlement. These are only supported on macOS and are not granted
+// by provisioning profiles.
+func MacOnly(key string) bool {
+	return sandboxKeys[key] || hardenedRuntimeKeys[key]
+}
+
+// AppSandbox allows you to restrict the app's access to system resources and
+// user data on macOS. Specifying any of the sandbox exceptions enables the
+// App Sandbox.
+// See https://developer.apple.com/documentation/security/app_sandbox for more information.
+type AppSandbox struct {
+	enabled           bool
+	networkClient     bool
+	networkServer     bool
+	userSelectedFiles FileAccess
+	downloads         FileAccess
+	camera            bool
+	microphone        bool
+	usb               bool
+	printing          bool
+}
+
+// Apply will apply the App Sandbox entitlements
+func (s *AppSandbox) Apply(data map[string]interface{}) {
+	if !s.enabled {
+		return
+	}
+
+	data["com.apple.security.app-sandbox"] = true
+
+	flags := map[string]bool{
+		"com.apple.security.network.client":    s.networkClient,
+		"com.apple.security.network.server":    s.networkServer,
+		"com.apple.security.device.camera":     s.camera,
+		"com.apple.security.device.microphone": s.microphone,
+		"com.apple.security.device.usb":        s.usb,
+		"com.apple.security.print":             s.printing,
+	}
+
+	for key, enabled := range flags {
+		if enabled {
+			data[key] = true
+		}
+	}
+
+	if s.userSelectedFiles != "" {
+		data["com.apple.security.files.user-selected."+string(s.userSelectedFiles)] = true
+	}
+
+	if s.downloads != "" {
+		data["com.apple.security.files.downloads."+string(s.downloads)] = true
+	}
+}
+
+// Enable enables the App Sandbox.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_app-sandbox for more information.
+func (s *AppSandbox) Enable() {
+	s.enabled = true
+}
+
+// NetworkClient allows outgoing network connections.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_network_client for more information.
+func (s *AppSandbox) NetworkClient() {
+	s.enabled = true
+	s.networkClient = true
+}
+
+// NetworkServer allows incoming network connections.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_network_server for more information.
+func (s *AppSandbox) NetworkServer() {
+	s.enabled = true
+	s.networkServer = true
+}
+
+// UserSelectedFiles allows access to files the user selects in an open or
+// save dialog.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_files_user-selected_read-write for more information.
+func (s *AppSandbox) UserSelectedFiles(access FileAccess) {
+	s.enabled = true
+	s.userSelectedFiles = access
+}
+
+// Downloads allows access to the user's Downloads folder.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_files_downloads_read-write for more information.
+func (s *AppSandbox) Downloads(access FileAccess) {
+	s.enabled = true
+	s.downloads = access
+}
+
+// Camera allows access to the built-in and external cameras.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_device_camera for more information.
+func (s *AppSandbox) Camera() {
+	s.enabled = true
+	s.camera = true
+}
+
+// Microphone allows access to the microphone.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_device_microphone for more information.
+func (s *AppSandbox) Microphone() {
+	s.enabled = true
+	s.microphone = true
+}
+
+// USB allows interaction with USB devices.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_device_usb for more information.
+func (s *AppSandbox) USB() {
+	s.enabled = true
+	s.usb = true
+}
+
+// Printing allows printing documents.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_print for more information.
+func (s *AppSandbox) Printing() {
+	s.enabled = true
+	s.printing = true
+}
+
+// HardenedRuntime allows you to specify the Hardened Runtime exceptions of a
+// notarized macOS app.
+// See https://developer.apple.com/documentation/security/hardened_runtime for more information.
+type HardenedRuntime struct {
+	allowJIT                      bool
+	allowUnsignedExecutableMemory bool
+	disableLibraryValidation      bool
+}
+
+// Apply will apply the Hardened Runtime entitlements
+func (h *HardenedRuntime) Apply(data map[string]interface{}) {
+	if h.allowJIT {
+		data["com.apple.security.cs.allow-jit"] = true
+	}
+
+	if h.allowUnsignedExecutableMemory {
+		data["com.apple.security.cs.allow-unsigned-executable-memory"] = true
+	}
+
+	if h.disableLibraryValidation {
+		data["com.apple.security.cs.disable-library-validation"] = true
+	}
+}
+
+// AllowJIT allows creating writable and executable memory using the `MAP_JIT`
+// flag.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_cs_allow-jit for more information.
+func (h *HardenedRuntime) AllowJIT() {
+	h.allowJIT = true
+}
+
+// AllowUnsignedExecutableMemory allows creating writable and executable
+// memory without the `MAP_JIT` flag.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_cs_allow-unsigned-executable-memory for more information.
+func (h *HardenedRuntime) AllowUnsignedExecutableMemory() {
+	h.allowUnsignedExecutableMemory = true
+}
+
+// DisableLibraryValidation allows loading frameworks, plug-ins and libraries
+// signed by other teams.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_security_cs_disable-library-validation for more information.
+func (h *HardenedRuntime) DisableLibraryValidation() {
+	h.disableLibraryValidation = true
+}
@@ -0,0 +1,95 @@
+package entitlements
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAppSandbox_Apply(t *testing.T) {
+	data := map[string]interface{}{}
+
+	s := AppSandbox{}
+	s.Apply(data)
+	assert.Empty(t, data)
+
+	s.NetworkClient()
+	s.NetworkServer()
+	s.UserSelectedFiles(FileAccessReadOnly)
+	s.Downloads(FileAccessReadWrite)
+	s.Camera()
+	s.Microphone()
+	s.USB()
+	s.Printing()
+	s.Apply(data)
+
+	assert.Equal(t, map[string]interface{}{
+		"com.apple.security.app-sandbox":                   true,
+		"com.apple.security.network.client":                true,
+		"com.apple.security.network.server":                true,
+		"com.apple.security.files.user-selected.read-only": true,
+		"com.apple.security.files.downloads.read-write":    true,
+		"com.apple.security.device.camera":                 true,
+		"com.apple.security.device.microphone":             true,
+		"com.apple.security.device.usb":                    true,
+		"com.apple.security.print":                         true,
+	}, data)
+}
+
+func TestAppSandbox_Enable(t *testing.T) {
+	s := AppSandbox{}
+	s.Enable()
+
+	data := map[string]interface{}{}
+
+	s.Apply(data)
+	assert.Equal(t, map[string]interface{}{"com.apple.security.app-sandbox": true}, data)
+}
+
+func TestHardenedRuntime_Apply(t *testing.T) {
+	h := HardenedRuntime{}
+	h.AllowJIT()
+	h.AllowUnsignedExecutableMemory()
+	h.DisableLibraryValidation()
+
+	data := map[string]interface{}{}
+
+	h.Apply(data)
+	assert.Equal(t, true, data["com.apple.security.cs.allow-jit"])
+	assert.Equal(t, true, data["com.apple.security.cs.allow-unsigned-executable-memory"])
+	assert.Equal(t, true, data["com.apple.security.cs.disable-library-validation"])
+}
+
+func TestMacOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{
+			name: "App Sandbox entitlements should be macOS only",
+			key:  "com.apple.security.files.bookmarks.app-scope",
+			want: true,
+		},
+		{
+			name: "Hardened Runtime entitlements should be macOS only",
+			key:  "com.apple.security.cs.allow-jit",
+			want: true,
+		},
+		{
+			name: "App groups should be supported on every platform",
+			key:  "com.apple.security.application-groups",
+			want: false,
+		},
+		{
+			name: "Push notifications should be supported on every platform",
+			key:  "aps-environment",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MacOnly(tt.key))
+		})
+	}
+}
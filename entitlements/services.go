@@ -0,0 +1,118 @@
+package entitlements
+
+// SignInWithApple allows you to enable Sign in with Apple.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_applesignin for more information.
+type SignInWithApple struct {
+	enabled bool
+}
+
+// Apply will apply the Sign in with Apple entitlements
+func (s *SignInWithApple) Apply(data map[string]interface{}) {
+	if s.enabled {
+		data["com.apple.developer.applesignin"] = []string{"Default"}
+	}
+}
+
+// Enable enables Sign in with Apple.
+func (s *SignInWithApple) Enable() {
+	s.enabled = true
+}
+
+// HealthKit allows you to enable access to health data.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_healthkit for more information.
+type HealthKit struct {
+	enabled            bool
+	clinicalRecords    bool
+	backgroundDelivery bool
+}
+
+// Apply will apply the HealthKit entitlements
+func (h *HealthKit) Apply(data map[string]interface{}) {
+	if !h.enabled {
+		return
+	}
+
+	data["com.apple.developer.healthkit"] = true
+
+	if h.clinicalRecords {
+		data["com.apple.developer.healthkit.access"] = []string{"health-records"}
+	}
+
+	if h.backgroundDelivery {
+		data["com.apple.developer.healthkit.background-delivery"] = true
+	}
+}
+
+// Enable enables HealthKit.
+func (h *HealthKit) Enable() {
+	h.enabled = true
+}
+
+// ClinicalRecords enables HealthKit and access to clinical health records.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_healthkit_access for more information.
+func (h *HealthKit) ClinicalRecords() {
+	h.enabled = true
+	h.clinicalRecords = true
+}
+
+// BackgroundDelivery enables HealthKit and the delivery of updates while the
+// app is in the background.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_healthkit_background-delivery for more information.
+func (h *HealthKit) BackgroundDelivery() {
+	h.enabled = true
+	h.backgroundDelivery = true
+}
+
+// HomeKit allows you to enable interaction with HomeKit accessories.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_homekit for more information.
+type HomeKit struct {
+	enabled bool
+}
+
+// Apply will apply the HomeKit entitlements
+func (h *HomeKit) Apply(data map[string]interface{}) {
+	if h.enabled {
+		data["com.apple.developer.homekit"] = true
+	}
+}
+
+// Enable enables HomeKit.
+func (h *HomeKit) Enable() {
+	h.enabled = true
+}
+
+// Siri allows you to enable handling Siri requests.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_siri for more information.
+type Siri struct {
+	enabled bool
+}
+
+// Apply will apply the Siri entitlements
+func (s *Siri) Apply(data map[string]interface{}) {
+	if s.enabled {
+		data["com.apple.developer.siri"] = true
+	}
+}
+
+// Enable enables Siri.
+func (s *Siri) Enable() {
+	s.enabled = true
+}
+
+// PushToTalk allows you to enable the Push to Talk framework.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_push-to-talk for more information.
+type PushToTalk struct {
+	enabled bool
+}
+
+// Apply will apply the Push to Talk entitlements
+func (p *PushToTalk) Apply(data map[string]interface{}) {
+	if p.enabled {
+		data["com.apple.developer.push-to-talk"] = true
+	}
+}
+
+// Enable enables Push to Talk.
+func (p *PushToTalk) Enable() {
+	p.enabled = true
+}
@@ -0,0 +1,52 @@
+package entitlements
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSignInWithApple_Apply(t *testing.T) {
+	s := SignInWithApple{}
+	s.Enable()
+
+	data := map[string]interface{}{}
+
+	s.Apply(data)
+	assert.Equal(t, []string{"Default"}, data["com.apple.developer.applesignin"])
+}
+
+func TestHealthKit_Apply(t *testing.T) {
+	data := map[string]interface{}{}
+
+	h := HealthKit{}
+	h.Apply(data)
+	assert.Empty(t, data)
+
+	h.ClinicalRecords()
+	h.BackgroundDelivery()
+	h.Apply(data)
+	assert.Equal(t, true, data["com.apple.developer.healthkit"])
+	assert.Equal(t, []string{"health-records"}, data["com.apple.developer.healthkit.access"])
+	assert.Equal(t, true, data["com.apple.developer.healthkit.background-delivery"])
+}
+
+func TestServices_Enable(t *testing.T) {
+	data := map[string]interface{}{}
+
+	homeKit := HomeKit{}
+	homeKit.Enable()
+	homeKit.Apply(data)
+
+	siri := Siri{}
+	siri.Enable()
+	siri.Apply(data)
+
+	pushToTalk := PushToTalk{}
+	pushToTalk.Enable()
+	pushToTalk.Apply(data)
+
+	assert.Equal(t, true, data["com.apple.developer.homekit"])
+	assert.Equal(t, true, data["com.apple.developer.siri"])
+	assert.Equal(t, true, data["com.apple.developer.push-to-talk"])
+}
@@ -0,0 +1,45 @@
+package entitlements
+
+// Wallet allows you to specify the pass types the app can access in Wallet.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_pass-type-identifiers for more information.
+type Wallet struct {
+	passTypes []string
+}
+
+// Apply will apply the Wallet entitlements
+func (w *Wallet) Apply(data map[string]interface{}) {
+	if len(w.passTypes) > 0 {
+		data["com.apple.developer.pass-type-identifiers"] = w.passTypes
+	}
+}
+
+// PassTypes specifies the pass type identifiers, i.e.
+// `$(TeamIdentifierPrefix)pass.com.best.app`.
+func (w *Wallet) PassTypes(ids ...string) {
+	w.passTypes = appendUnique(w.passTypes, ids...)
+}
+
+// AllPassTypes specifies the app can access every pass type of the team.
+func (w *Wallet) AllPassTypes() {
+	w.PassTypes("$(TeamIdentifierPrefix)*")
+}
+
+// InAppPayments allows you to specify the Apple Pay merchant IDs the app
+// uses.
+// See https://developer.apple.com/documentation/bundleresources/entitlements/com_apple_developer_in-app-payments for more information.
+type InAppPayments struct {
+	merchantIDs []string
+}
+
+// Apply will apply the in-app payments entitlements
+func (p *InAppPayments) Apply(data map[string]interface{}) {
+	if len(p.merchantIDs) > 0 {
+		data["com.apple.developer.in-app-payments"] = p.merchantIDs
+	}
+}
+
+// MerchantIDs specifies the Apple Pay merchant IDs, i.e.
+// `merchant.com.best.app`.
+func (p *InAppPayments) MerchantIDs(ids ...string) {
+	p.merchantIDs = appendUnique(p.merchantIDs, ids...)
+}
@@ -0,0 +1,31 @@
+package entitlements
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestWallet_Apply(t *testing.T) {
+	w := Wallet{}
+	w.PassTypes("$(TeamIdentifierPrefix)pass.com.best.app")
+	w.AllPassTypes()
+
+	data := map[string]interface{}{}
+
+	w.Apply(data)
+	assert.Equal(t, []string{
+		"$(TeamIdentifierPrefix)pass.com.best.app",
+		"$(TeamIdentifierPrefix)*",
+	}, data["com.apple.developer.pass-type-identifiers"])
+}
+
+func TestInAppPayments_Apply(t *testing.T) {
+	p := InAppPayments{}
+	p.MerchantIDs("merchant.com.best.app", "merchant.com.best.app")
+
+	data := map[string]interface{}{}
+
+	p.Apply(data)
+	assert.Equal(t, []string{"merchant.com.best.app"}, data["com.apple.developer.in-app-payments"])
+}
@@ -0,0 +1,285 @@
+// Package filesystem provides the writable file systems the apptools builders
+// save their output to, such as asset catalogs and localized strings. Use
+// `OSFileSystem` to write to disk or `NewMemoryFileSystem` to render in memory,
+// i.e. for tests or to build an archive.
+package filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileSystem is a writable file system that generated files are saved to. Use
+// `OSFileSystem` to write to disk, `NewMemoryFileSystem` to render files in
+// memory or provide your own implementation.
+type FileSystem interface {
+	Stat(name string) (os.FileInfo, error)
+	ReadDir(name string) ([]os.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	RemoveAll(path string) error
+	Rename(oldpath, newpath string) error
+}
+
+// OSFileSystem is a `FileSystem` backed by the operating system.
+type OSFileSystem struct{}
+
+// Stat returns the file info for the named file.
+func (OSFileSystem) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// ReadDir returns the entries of the named directory sorted by name.
+func (OSFileSystem) ReadDir(name string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(name)
+}
+
+// ReadFile returns the contents of the named file.
+func (OSFileSystem) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(name)
+}
+
+// WriteFile writes data to the named file, creating it if necessary.
+func (OSFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return ioutil.WriteFile(name, data, perm)
+}
+
+// MkdirAll creates a directory along with any necessary parents.
+func (OSFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// RemoveAll removes path and any children it contains.
+func (OSFileSystem) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// Rename renames oldpath to newpath.
+func (OSFileSystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// MemoryFileSystem is an in-memory `FileSystem`. It is safe for concurrent use.
+type MemoryFileSystem struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// NewMemoryFileSystem returns an empty in-memory file system containing only
+// the current directory.
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{
+		files: map[string][]byte{},
+		dirs:  map[string]bool{".": true, "/": true},
+	}
+}
+
+type memoryFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i memoryFileInfo) Name() string { return i.name }
+func (i memoryFileInfo) Size() int64  { return i.size }
+func (i memoryFileInfo) Mode() os.FileMode {
+	if i.dir {
+		return os.ModeDir | os.ModePerm
+	}
+	return os.ModePerm
+}
+func (i memoryFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memoryFileInfo) IsDir() bool        { return i.dir }
+func (i memoryFileInfo) Sys() interface{}   { return nil }
+
+func memoryNotExist(op, name string) error {
+	return &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+}
+
+// Files returns the paths of all files in the file system, sorted by name.
+func (m *MemoryFileSystem) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	paths := make([]string, 0, len(m.files))
+	for path := range m.files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// Stat returns the file info for the named file.
+func (m *MemoryFileSystem) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if data, ok := m.files[name]; ok {
+		return memoryFileInfo{name: filepath.Base(name), size: int64(len(data))}, nil
+	}
+
+	if m.dirs[name] {
+		return memoryFileInfo{name: filepath.Base(name), dir: true}, nil
+	}
+
+	return nil, memoryNotExist("stat", name)
+}
+
+// ReadDir returns the entries of the named directory sorted by name.
+func (m *MemoryFileSystem) ReadDir(name string) ([]os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if !m.dirs[name] {
+		return nil, memoryNotExist("readdir", name)
+	}
+
+	entries := []os.FileInfo{}
+	for path, data := range m.files {
+		if filepath.Dir(path) == name {
+			entries = append(entries, memoryFileInfo{name: filepath.Base(path), size: int64(len(data))})
+		}
+	}
+
+	for path := range m.dirs {
+		if path != name && filepath.Dir(path) == name {
+			entries = append(entries, memoryFileInfo{name: filepath.Base(path), dir: true})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries, nil
+}
+
+// ReadFile returns the contents of the named file.
+func (m *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.files[filepath.Clean(name)]
+	if !ok {
+		return nil, memoryNotExist("open", name)
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile writes data to the named file. The parent directory must exist.
+func (m *MemoryFileSystem) WriteFile(name string, data []byte, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name = filepath.Clean(name)
+	if !m.dirs[filepath.Dir(name)] {
+		return memoryNotExist("open", name)
+	}
+
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// MkdirAll creates a directory along with any necessary parents.
+func (m *MemoryFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Stops at the root, which is its own parent, i.e. `C:\` on Windows.
+	for path = filepath.Clean(path); !m.dirs[path]; path = filepath.Dir(path) {
+		m.dirs[path] = true
+
+		if filepath.Dir(path) == path {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryFileSystem) within(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// RemoveAll removes path and any children it contains.
+func (m *MemoryFileSystem) RemoveAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path = filepath.Clean(path)
+	for name := range m.files {
+		if m.within(name, path) {
+			delete(m.files, name)
+		}
+	}
+
+	for name := range m.dirs {
+		if m.within(name, path) {
+			delete(m.dirs, name)
+		}
+	}
+
+	return nil
+}
+
+// Rename renames oldpath, and any children it contains, to newpath.
+func (m *MemoryFileSystem) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldpath, newpath = filepath.Clean(oldpath), filepath.Clean(newpath)
+	if _, ok := m.files[oldpath]; !ok && !m.dirs[oldpath] {
+		return memoryNotExist("rename", oldpath)
+	}
+
+	if newpath == oldpath {
+		return nil
+	}
+
+	if m.dirs[newpath] {
+		return &os.PathError{Op: "rename", Path: newpath, Err: os.ErrExist}
+	}
+
+	if m.within(newpath, oldpath) {
+		return &os.PathError{Op: "rename", Path: newpath, Err: os.ErrInvalid}
+	}
+
+	// Collect the renames first, since keys added while ranging over a map
+	// may be visited again.
+	files := map[string][]byte{}
+	for name, data := range m.files {
+		if m.within(name, oldpath) {
+			files[name] = data
+		}
+	}
+
+	dirs := []string{}
+	for name := range m.dirs {
+		if m.within(name, oldpath) {
+			dirs = append(dirs, name)
+		}
+	}
+
+	for name, data := range files {
+		delete(m.files, name)
+		m.files[newpath+strings.TrimPrefix(name, oldpath)] = data
+	}
+
+	for _, name := range dirs {
+		delete(m.dirs, name)
+		m.dirs[newpath+strings.TrimPrefix(name, oldpath)] = true
+	}
+
+	return nil
+}
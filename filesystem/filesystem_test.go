@@ -0,0 +1,119 @@
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestMemoryFileSystem_Rename(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldpath string
+		newpath string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Renaming a directory should move its children",
+			oldpath: "a",
+			newpath: "d",
+			want:    []string{"d/b/c.txt"},
+		},
+		{
+			name:    "Renaming a nested directory should move its children",
+			oldpath: "a/b",
+			newpath: "a/bb",
+			want:    []string{"a/bb/c.txt"},
+		},
+		{
+			name:    "Renaming a file should move it",
+			oldpath: "a/b/c.txt",
+			newpath: "a/c.txt",
+			want:    []string{"a/c.txt"},
+		},
+		{
+			name:    "Renaming a directory to itself should do nothing",
+			oldpath: "a/b",
+			newpath: "a/b",
+			want:    []string{"a/b/c.txt"},
+		},
+		{
+			name:    "Renaming a directory into itself should fail",
+			oldpath: "a",
+			newpath: "a/b/d",
+			want:    []string{"a/b/c.txt"},
+			wantErr: os.ErrInvalid,
+		},
+		{
+			name:    "Renaming onto an existing directory should fail",
+			oldpath: "a/b",
+			newpath: "a",
+			want:    []string{"a/b/c.txt"},
+			wantErr: os.ErrExist,
+		},
+		{
+			name:    "Renaming a missing path should fail",
+			oldpath: "x",
+			newpath: "y",
+			want:    []string{"a/b/c.txt"},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := NewMemoryFileSystem()
+			assert.Nil(t, fsys.MkdirAll("a/b", os.ModePerm))
+			assert.Nil(t, fsys.WriteFile("a/b/c.txt", []byte("c"), os.ModePerm))
+
+			err := fsys.Rename(tt.oldpath, tt.newpath)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, tt.want, fsys.Files())
+
+			if tt.wantErr == nil && tt.oldpath != tt.newpath {
+				_, err = fsys.Stat(tt.oldpath)
+				assert.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
+
+func TestMemoryFileSystem_WriteFile(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+	assert.NotNil(t, fsys.WriteFile("x/c.txt", []byte("c"), os.ModePerm), "Writing to a missing directory should fail")
+
+	assert.Nil(t, fsys.MkdirAll("x", os.ModePerm))
+	assert.Nil(t, fsys.WriteFile("x/c.txt", []byte("c"), os.ModePerm))
+
+	data, err := fsys.ReadFile("x/c.txt")
+	assert.Nil(t, err)
+	assert.Equal(t, "c", string(data))
+
+	entries, err := fsys.ReadDir("x")
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "c.txt", entries[0].Name())
+}
+
+func TestMemoryFileSystem_MkdirAllAbsolute(t *testing.T) {
+	fsys := NewMemoryFileSystem()
+
+	dir, err := filepath.Abs(filepath.Join("assets", "Logo.imageset"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, fsys.MkdirAll(dir, os.ModePerm))
+	assert.Nil(t, fsys.WriteFile(filepath.Join(dir, "Contents.json"), []byte("{}"), os.ModePerm))
+
+	root := filepath.VolumeName(dir) + string(filepath.Separator)
+	stat, err := fsys.Stat(root)
+	assert.Nil(t, err)
+	assert.True(t, stat.IsDir())
+}
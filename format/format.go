@@ -0,0 +1,142 @@
+// Package format encodes the property lists built by the apptools builders,
+// such as `Info.plist` and `App.entitlements`, in the formats understood by
+// Xcode and `plutil`. Dictionary keys are always sorted so the output is
+// byte-stable across runs.
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"howett.net/plist"
+)
+
+// Format is a property list output format.
+type Format int
+
+// Supported output formats.
+const (
+	// XML is single-line XML, the default output of the builders.
+	XML Format = iota
+
+	// PrettyXML is XML indented with tabs, matching the files written by
+	// Xcode.
+	PrettyXML
+
+	// Binary is the binary property list format, i.e. `bplist00`.
+	Binary
+
+	// OpenStep is the OpenStep text format. OpenStep has no boolean type, so
+	// booleans are written as `1` and `0`.
+	OpenStep
+
+	// JSON is indented JSON, as read by tooling consuming `plutil -convert
+	// json` output. JSON cannot represent dates and data the way property
+	// lists do, so dates are written as RFC 3339 strings and data as base64
+	// strings.
+	JSON
+)
+
+var emptyCollectionRegex = regexp.MustCompile(`<(array|dict)>\n\t*</(array|dict)>`)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case XML:
+		return "xml"
+	case PrettyXML:
+		return "pretty-xml"
+	case Binary:
+		return "binary"
+	case OpenStep:
+		return "openstep"
+	case JSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// Encode encodes the value, typically a property list dictionary, in the
+// specified format.
+func Encode(v interface{}, f Format) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	var encoder *plist.Encoder
+
+	switch f {
+	case XML:
+		encoder = plist.NewEncoder(&buf)
+	case PrettyXML:
+		return encodePrettyXML(v)
+	case Binary:
+		encoder = plist.NewBinaryEncoder(&buf)
+	case OpenStep:
+		encoder = plist.NewEncoderForFormat(&buf, plist.OpenStepFormat)
+		encoder.Indent("\t")
+	case JSON:
+		return encodeJSON(v)
+	default:
+		return nil, fmt.Errorf("Unsupported format: %v", f)
+	}
+
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	if f == OpenStep {
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// encodePrettyXML encodes the value as XML in the style written by Xcode: the
+// root element is not indented, empty arrays and dictionaries are
+// self-closing and the file ends with a newline.
+func encodePrettyXML(v interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	encoder := plist.NewEncoder(&buf)
+	encoder.Indent("\t")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	for idx, line := range lines {
+		// Element lines always start with '<' once indented, whereas '<' is
+		// escaped within values.
+		if strings.HasPrefix(line, "\t") && strings.HasPrefix(strings.TrimLeft(line, "\t"), "<") {
+			lines[idx] = line[1:]
+		}
+	}
+
+	out := strings.Join(lines, "\n")
+	out = emptyCollectionRegex.ReplaceAllStringFunc(out, func(m string) string {
+		parts := emptyCollectionRegex.FindStringSubmatch(m)
+		if parts[1] != parts[2] {
+			return m
+		}
+
+		return "<" + parts[1] + "/>"
+	})
+
+	return []byte(out + "\n"), nil
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
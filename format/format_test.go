@@ -0,0 +1,143 @@
+package format
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"howett.net/plist"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   map[string]interface{}
+		format Format
+		want   string
+	}{
+		{
+			name: "Pretty XML should indent with tabs and collapse empty collections",
+			data: map[string]interface{}{
+				"CFBundleName":         "Best & App",
+				"CFBundleVersion":      "1",
+				"LSRequiresIPhoneOS":   true,
+				"UIRequiredCapability": []string{"armv7"},
+				"UIBackgroundModes":    []string{},
+				"NSAppTransportSecurity": map[string]interface{}{
+					"NSAllowsArbitraryLoads": false,
+				},
+				"NSExtension": map[string]interface{}{},
+			},
+			format: PrettyXML,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleName</key>
+	<string>Best &amp; App</string>
+	<key>CFBundleVersion</key>
+	<string>1</string>
+	<key>LSRequiresIPhoneOS</key>
+	<true/>
+	<key>NSAppTransportSecurity</key>
+	<dict>
+		<key>NSAllowsArbitraryLoads</key>
+		<false/>
+	</dict>
+	<key>NSExtension</key>
+	<dict/>
+	<key>UIBackgroundModes</key>
+	<array/>
+	<key>UIRequiredCapability</key>
+	<array>
+		<string>armv7</string>
+	</array>
+</dict>
+</plist>
+`,
+		},
+		{
+			name:   "XML should sort keys without indentation",
+			data:   map[string]interface{}{"b": "2", "a": "1"},
+			format: XML,
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0"><dict><key>a</key><string>1</string><key>b</key><string>2</string></dict></plist>`,
+		},
+		{
+			name: "OpenStep should quote strings containing spaces",
+			data: map[string]interface{}{
+				"CFBundleName":      "Best App",
+				"CFBundleVersion":   "1",
+				"UIBackgroundModes": []string{"audio", "fetch"},
+			},
+			format: OpenStep,
+			want: `{
+	CFBundleName = "Best App";
+	CFBundleVersion = 1;
+	UIBackgroundModes = (
+		audio,
+		fetch,
+	);
+}
+`,
+		},
+		{
+			name: "JSON should indent and not escape HTML characters",
+			data: map[string]interface{}{
+				"CFBundleName":      "Best & App",
+				"UIBackgroundModes": []string{"audio"},
+			},
+			format: JSON,
+			want: `{
+  "CFBundleName": "Best & App",
+  "UIBackgroundModes": [
+    "audio"
+  ]
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Encode(tt.data, tt.format)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, string(out))
+
+			for i := 0; i < 20; i++ {
+				again, err := Encode(tt.data, tt.format)
+				assert.Nil(t, err)
+				assert.Equal(t, out, again, "Output should be byte-stable across runs")
+			}
+		})
+	}
+}
+
+func TestEncode_Binary(t *testing.T) {
+	data := map[string]interface{}{
+		"CFBundleName":       "Best & App",
+		"LSRequiresIPhoneOS": true,
+		"UIBackgroundModes":  []string{"audio", "fetch"},
+	}
+
+	out, err := Encode(data, Binary)
+	assert.Nil(t, err)
+	assert.Equal(t, "bplist00", string(out[:8]))
+
+	for i := 0; i < 20; i++ {
+		again, err := Encode(data, Binary)
+		assert.Nil(t, err)
+		assert.Equal(t, out, again, "Output should be byte-stable across runs")
+	}
+
+	decoded := map[string]interface{}{}
+	format, err := plist.Unmarshal(out, &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, plist.BinaryFormat, format)
+	assert.Equal(t, "Best & App", decoded["CFBundleName"])
+	assert.Equal(t, true, decoded["LSRequiresIPhoneOS"])
+}
+
+func TestEncode_UnsupportedFormat(t *testing.T) {
+	_, err := Encode(map[string]interface{}{"CFBundleName": "Best App"}, Format(42))
+	assert.EqualError(t, err, "Unsupported format: Format(42)")
+}
@@ -12,9 +12,10 @@ type AppTransportSecurity struct {
 	exceptionDomains map[string]*ATSExceptionDomain
 }
 
-// Apply will apply AppTransportSecurity against the specified PropertyList.
-func (s *AppTransportSecurity) Apply(p *PropertyList) {
-	p.Set(keyNSAppTransportSecurity, s.build())
+// Apply will apply AppTransportSecurity to the specified property list
+// dictionary.
+func (s *AppTransportSecurity) Apply(data map[string]interface{}) {
+	data[keyNSAppTransportSecurity] = s.build()
 }
 
 // AllowArbitraryLoads specifies a boolean value indicating whether App
@@ -0,0 +1,257 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for background execution and queried schemes.
+const (
+	CodeMissingPermittedIdentifiers = "missing-permitted-identifiers"
+	CodeUnusedPermittedIdentifiers  = "unused-permitted-identifiers"
+	CodeMissingUsageDescription     = "missing-usage-description"
+	CodeTooManyQueriesSchemes       = "too-many-queries-schemes"
+)
+
+// maxQueriesSchemes is the number of `LSApplicationQueriesSchemes` entries
+// iOS honors. Any additional schemes are ignored by `canOpenURL(_:)`.
+const maxQueriesSchemes = 50
+
+var (
+	// ErrMissingPermittedIdentifiers is the warning returned when the
+	// `processing` background mode is enabled without any permitted
+	// BGTaskScheduler identifiers.
+	ErrMissingPermittedIdentifiers = errors.New("Background processing is enabled without any permitted BGTaskScheduler identifiers")
+
+	// ErrUnusedPermittedIdentifiers is the warning returned when permitted
+	// BGTaskScheduler identifiers are specified without the `fetch` or
+	// `processing` background modes.
+	ErrUnusedPermittedIdentifiers = errors.New("BGTaskScheduler identifiers require the fetch or processing background mode")
+
+	// ErrMissingUsageDescription is the warning returned when a feature is
+	// enabled without the privacy usage description it requires.
+	ErrMissingUsageDescription = errors.New("Missing usage description")
+
+	// ErrTooManyQueriesSchemes is the warning returned when more schemes are
+	// queried than iOS allows.
+	ErrTooManyQueriesSchemes = fmt.Errorf("Only the first %d queried URL schemes are honored", maxQueriesSchemes)
+)
+
+// Background modes supported by `BackgroundModes`.
+const (
+	backgroundModeAudio               = "audio"
+	backgroundModeLocation            = "location"
+	backgroundModeVoIP                = "voip"
+	backgroundModeExternalAccessory   = "external-accessory"
+	backgroundModeBluetoothCentral    = "bluetooth-central"
+	backgroundModeBluetoothPeripheral = "bluetooth-peripheral"
+	backgroundModeFetch               = "fetch"
+	backgroundModeRemoteNotification  = "remote-notification"
+	backgroundModeProcessing          = "processing"
+	backgroundModeNearbyInteraction   = "nearby-interaction"
+	backgroundModePushToTalk          = "push-to-talk"
+)
+
+// BackgroundModes allows you to specify the services the app provides that
+// require it to continue running in the background.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uibackgroundmodes for more information.
+type BackgroundModes struct {
+	modes []string
+}
+
+// Apply will apply the background modes to the specified property list
+// dictionary.
+func (m *BackgroundModes) Apply(data map[string]interface{}) {
+	if len(m.modes) > 0 {
+		data[keyUIBackgroundModes] = m.modes
+	}
+}
+
+// Set a custom background mode. This can be used if no built-in method is
+// provided for your mode.
+func (m *BackgroundModes) Set(mode string) *BackgroundModes {
+	if !m.has(mode) {
+		m.modes = append(m.modes, mode)
+	}
+
+	return m
+}
+
+func (m *BackgroundModes) has(mode string) bool {
+	for _, existing := range m.modes {
+		if existing == mode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Audio specifies the app plays audible content in the background.
+func (m *BackgroundModes) Audio() *BackgroundModes {
+	return m.Set(backgroundModeAudio)
+}
+
+// Location specifies the app provides location-based information in the
+// background. Requires a location usage description in `Privacy`.
+func (m *BackgroundModes) Location() *BackgroundModes {
+	return m.Set(backgroundModeLocation)
+}
+
+// VoIP specifies the app provides Voice-over-IP services.
+func (m *BackgroundModes) VoIP() *BackgroundModes {
+	return m.Set(backgroundModeVoIP)
+}
+
+// ExternalAccessory specifies the app communicates with accessories using the
+// External Accessory framework.
+func (m *BackgroundModes) ExternalAccessory() *BackgroundModes {
+	return m.Set(backgroundModeExternalAccessory)
+}
+
+// BluetoothCentral specifies the app communicates with Bluetooth LE
+// accessories. Requires a Bluetooth usage description in `Privacy`.
+func (m *BackgroundModes) BluetoothCentral() *BackgroundModes {
+	return m.Set(backgroundModeBluetoothCentral)
+}
+
+// BluetoothPeripheral specifies the app shares data using Bluetooth LE.
+// Requires a Bluetooth usage description in `Privacy`.
+func (m *BackgroundModes) BluetoothPeripheral() *BackgroundModes {
+	return m.Set(backgroundModeBluetoothPeripheral)
+}
+
+// Fetch specifies the app downloads and processes small amounts of content
+// periodically.
+func (m *BackgroundModes) Fetch() *BackgroundModes {
+	return m.Set(backgroundModeFetch)
+}
+
+// RemoteNotification specifies the app downloads content in response to push
+// notifications.
+func (m *BackgroundModes) RemoteNotification() *BackgroundModes {
+	return m.Set(backgroundModeRemoteNotification)
+}
+
+// Processing specifies the app performs BGProcessingTask background tasks.
+// The task identifiers must be specified with
+// `PropertyList.PermittedBackgroundTasks`.
+func (m *BackgroundModes) Processing() *BackgroundModes {
+	return m.Set(backgroundModeProcessing)
+}
+
+// NearbyInteraction specifies the app interacts with nearby devices using the
+// Nearby Interaction framework.
+func (m *BackgroundModes) NearbyInteraction() *BackgroundModes {
+	return m.Set(backgroundModeNearbyInteraction)
+}
+
+// PushToTalk specifies the app provides push to talk services.
+func (m *BackgroundModes) PushToTalk() *BackgroundModes {
+	return m.Set(backgroundModePushToTalk)
+}
+
+// BackgroundModes specifies the services the app provides that require it to
+// continue running in the background.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uibackgroundmodes for more information.
+func (p *PropertyList) BackgroundModes(f func(m *BackgroundModes)) *PropertyList {
+	p.touch(keyUIBackgroundModes)
+	p.backgroundModes = &BackgroundModes{}
+	f(p.backgroundModes)
+	return p
+}
+
+// PermittedBackgroundTasks specifies the task identifiers the app may submit
+// to BGTaskScheduler, i.e. `com.best.app.refresh`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/bgtaskschedulerpermittedidentifiers for more information.
+func (p *PropertyList) PermittedBackgroundTasks(identifiers ...string) *PropertyList {
+	p.touch(keyBGTaskSchedulerPermittedIdentifiers)
+	p.backgroundTasks = append(p.backgroundTasks, identifiers...)
+	return p
+}
+
+// QueriesSchemes specifies the URL schemes the app can check with
+// `canOpenURL(_:)`. iOS honors at most 50 schemes.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/lsapplicationqueriesschemes for more information.
+func (p *PropertyList) QueriesSchemes(schemes ...string) *PropertyList {
+	p.touch(keyLSApplicationQueriesSchemes)
+	p.queriesSchemes = append(p.queriesSchemes, schemes...)
+	return p
+}
+
+func (p *PropertyList) applyBackground(data map[string]interface{}) {
+	if modes := p.backgroundModes; modes != nil {
+		modes.Apply(data)
+	}
+
+	if len(p.backgroundTasks) > 0 {
+		data[keyBGTaskSchedulerPermittedIdentifiers] = p.backgroundTasks
+	}
+
+	if len(p.queriesSchemes) > 0 {
+		data[keyLSApplicationQueriesSchemes] = p.queriesSchemes
+	}
+}
+
+// backgroundReport cross-checks the background modes against the permitted
+// background tasks and privacy descriptions, and validates the queried URL
+// schemes.
+func (p *PropertyList) backgroundReport() *validation.Report {
+	r := &validation.Report{}
+
+	modes := p.backgroundModes
+	if modes == nil {
+		modes = &BackgroundModes{}
+	}
+
+	if modes.has(backgroundModeProcessing) && len(p.backgroundTasks) == 0 {
+		r.AddWarning("PermittedBackgroundTasks", CodeMissingPermittedIdentifiers,
+			keyBGTaskSchedulerPermittedIdentifiers, ErrMissingPermittedIdentifiers)
+	}
+
+	if len(p.backgroundTasks) > 0 && !modes.has(backgroundModeProcessing) && !modes.has(backgroundModeFetch) {
+		r.AddWarning("PermittedBackgroundTasks", CodeUnusedPermittedIdentifiers,
+			keyBGTaskSchedulerPermittedIdentifiers, ErrUnusedPermittedIdentifiers)
+	}
+
+	descriptions := []struct {
+		mode string
+		keys []string
+	}{
+		{backgroundModeLocation, []string{privacyKeys["locationEverything"], privacyKeys["locationAlways"], privacyKeys["locationWhenInUse"]}},
+		{backgroundModeBluetoothCentral, []string{privacyKeys["bluetoothAlways"]}},
+		{backgroundModeBluetoothPeripheral, []string{privacyKeys["bluetoothAlways"]}},
+	}
+
+	for _, d := range descriptions {
+		if modes.has(d.mode) && !p.privacy.has(d.keys...) {
+			r.AddWarning("Privacy", CodeMissingUsageDescription, d.keys[0],
+				fmt.Errorf("%w for the %v background mode", ErrMissingUsageDescription, d.mode))
+		}
+	}
+
+	seen := map[string]bool{}
+	for idx, scheme := range p.queriesSchemes {
+		path := validation.Index("QueriesSchemes", idx)
+
+		if !urlSchemeRegex.MatchString(scheme) {
+			r.AddError(path, CodeInvalidURLScheme, keyLSApplicationQueriesSchemes,
+				fmt.Errorf("%w: %q", ErrInvalidURLScheme, scheme))
+		}
+
+		if seen[scheme] {
+			r.AddWarning(path, CodeDuplicateURLScheme, keyLSApplicationQueriesSchemes,
+				fmt.Errorf("%w: %q", ErrDuplicateURLScheme, scheme))
+		}
+
+		seen[scheme] = true
+	}
+
+	if len(p.queriesSchemes) > maxQueriesSchemes {
+		r.AddWarning("QueriesSchemes", CodeTooManyQueriesSchemes, keyLSApplicationQueriesSchemes, ErrTooManyQueriesSchemes)
+	}
+
+	return r
+}
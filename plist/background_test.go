@@ -0,0 +1,71 @@
+package plist
+
+import (
+	"fmt"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_BackgroundModes(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.BackgroundModes(func(m *BackgroundModes) {
+		m.Audio().Location().Processing().BluetoothCentral().Audio()
+	})
+	plist.QueriesSchemes("fb", "twitter", "fb", "not a scheme")
+
+	report := plist.backgroundReport()
+
+	warnings := []string{}
+	for _, issue := range report.Warnings() {
+		warnings = append(warnings, fmt.Sprintf("%v %v %v", issue.Path, issue.Code, issue.Key))
+	}
+
+	assert.Equal(t, []string{
+		"PermittedBackgroundTasks missing-permitted-identifiers BGTaskSchedulerPermittedIdentifiers",
+		"Privacy missing-usage-description NSLocationAlwaysAndWhenInUseUsageDescription",
+		"Privacy missing-usage-description NSBluetoothAlwaysUsageDescription",
+		"QueriesSchemes[2] duplicate-url-scheme LSApplicationQueriesSchemes",
+	}, warnings)
+
+	assert.Len(t, report.Errors(), 1)
+	assert.Equal(t, "QueriesSchemes[3]", report.Errors()[0].Path)
+
+	plist.PermittedBackgroundTasks("com.best.app.cleanup")
+	plist.Privacy(func(p *Privacy) {
+		p.LocationWhenInUse("We use your location to find nearby stores")
+		p.BluetoothAlways("We use Bluetooth to connect to your watch")
+	})
+
+	report = plist.backgroundReport()
+	assert.Len(t, report.Warnings(), 1)
+
+	data := plist.build()
+	assert.Equal(t, []string{"audio", "location", "processing", "bluetooth-central"}, data["UIBackgroundModes"])
+	assert.Equal(t, []string{"com.best.app.cleanup"}, data["BGTaskSchedulerPermittedIdentifiers"])
+	assert.Equal(t, []string{"fb", "twitter", "fb", "not a scheme"}, data["LSApplicationQueriesSchemes"])
+}
+
+func TestPropertyList_PermittedBackgroundTasks(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.PermittedBackgroundTasks("com.best.app.refresh")
+
+	warnings := plist.backgroundReport().Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodeUnusedPermittedIdentifiers, warnings[0].Code)
+
+	plist.BackgroundModes(func(m *BackgroundModes) {
+		m.Fetch()
+	})
+	assert.True(t, plist.backgroundReport().Empty())
+
+	schemes := make([]string, maxQueriesSchemes+1)
+	for idx := range schemes {
+		schemes[idx] = fmt.Sprintf("app%d", idx)
+	}
+
+	plist.QueriesSchemes(schemes...)
+	warnings = plist.backgroundReport().Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodeTooManyQueriesSchemes, warnings[0].Code)
+}
@@ -0,0 +1,109 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/illyabusigin/apptools/xcconfig"
+)
+
+// CodeUnresolvedVariable is the validation code reported for unresolved build
+// setting references.
+const CodeUnresolvedVariable = "unresolved-variable"
+
+// ErrUnresolvedVariable is the error returned for build setting references
+// that are not defined by the build settings.
+var ErrUnresolvedVariable = errors.New("Unresolved build setting")
+
+// BuildSettings specifies the Xcode build settings used to expand build
+// setting references such as `$(PRODUCT_BUNDLE_IDENTIFIER)` when building
+// the property list, i.e. the settings loaded with `xcconfig.Load`. When
+// specified, references that cannot be resolved are reported and validation
+// runs on the expanded values.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference for more information.
+func (p *PropertyList) BuildSettings(settings xcconfig.Settings) *PropertyList {
+	p.buildSettings = settings
+	return p
+}
+
+// expandBuildSettings returns a copy of the property list dictionary with the
+// build setting references of every string value expanded, along with the
+// unresolved references of each key.
+func expandBuildSettings(settings xcconfig.Settings, data map[string]interface{}) (map[string]interface{}, map[string][]string) {
+	expanded := make(map[string]interface{}, len(data))
+	unresolved := map[string][]string{}
+
+	for key, value := range data {
+		refs := []string{}
+		expanded[key] = expandValue(settings, value, &refs)
+
+		if len(refs) > 0 {
+			unresolved[key] = refs
+		}
+	}
+
+	return expanded, unresolved
+}
+
+func expandValue(settings xcconfig.Settings, v interface{}, unresolved *[]string) interface{} {
+	switch value := v.(type) {
+	case string:
+		expanded, refs := settings.Expand(value)
+		*unresolved = append(*unresolved, refs...)
+		return expanded
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(value))
+		for key, val := range value {
+			c[key] = expandValue(settings, val, unresolved)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(value))
+		for idx, val := range value {
+			c[idx] = expandValue(settings, val, unresolved)
+		}
+		return c
+	case []map[string]interface{}:
+		c := make([]map[string]interface{}, len(value))
+		for idx, val := range value {
+			c[idx] = expandValue(settings, val, unresolved).(map[string]interface{})
+		}
+		return c
+	case []string:
+		c := make([]string, len(value))
+		for idx, val := range value {
+			c[idx] = expandValue(settings, val, unresolved).(string)
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// buildSettingsReport reports the unresolved build setting references.
+func buildSettingsReport(unresolved map[string][]string) *validation.Report {
+	r := &validation.Report{}
+
+	unresolvedReport(r, "", unresolved)
+
+	return r
+}
+
+// unresolvedReport reports the unresolved build setting references of each
+// key.
+func unresolvedReport(r *validation.Report, path string, unresolved map[string][]string) {
+	keys := make([]string, 0, len(unresolved))
+	for key := range unresolved {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, ref := range unresolved[key] {
+			r.AddError(path, CodeUnresolvedVariable, key, fmt.Errorf("%w: $(%v)", ErrUnresolvedVariable, ref))
+		}
+	}
+}
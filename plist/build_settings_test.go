@@ -0,0 +1,177 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/illyabusigin/apptools/xcconfig"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_BuildSettings(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		contains []string
+		bundleID string
+	}{
+		{
+			name: "Build setting references should be expanded",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("$(PRODUCT_NAME)")
+					plist.BuildSettings(xcconfig.Settings{
+						"DEVELOPMENT_LANGUAGE":      "en",
+						"PRODUCT_NAME":              "Best App",
+						"PRODUCT_BUNDLE_IDENTIFIER": "com.best.$(PRODUCT_NAME:rfc1034identifier:lower)",
+						"EXECUTABLE_NAME":           "$(PRODUCT_NAME)",
+						"MARKETING_VERSION":         "1.2.0",
+						"MACOSX_DEPLOYMENT_TARGET":  "10.15",
+					})
+
+					return plist
+				},
+			},
+			contains: []string{
+				"<key>CFBundleIdentifier</key><string>com.best.best-app</string>",
+				"<key>CFBundleExecutable</key><string>Best App</string>",
+				"<key>CFBundleShortVersionString</key><string>1.2.0</string>",
+				"<key>LSMinimumSystemVersion</key><string>10.15</string>",
+			},
+			bundleID: "com.best.best-app",
+		},
+		{
+			name: "Missing build settings with a default value should be expanded",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.BundleID("com.best.$(BUNDLE_SUFFIX:default=app)")
+					plist.VersionShort("$(MARKETING_VERSION:default=1.0.0)")
+					plist.BuildSettings(xcconfig.Settings{
+						"DEVELOPMENT_LANGUAGE":     "en",
+						"EXECUTABLE_NAME":          "Best App",
+						"MACOSX_DEPLOYMENT_TARGET": "10.15",
+					})
+
+					return plist
+				},
+			},
+			contains: []string{
+				"<key>CFBundleIdentifier</key><string>com.best.app</string>",
+				"<key>CFBundleShortVersionString</key><string>1.0.0</string>",
+			},
+			bundleID: "com.best.app",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := tt.fields.plist()
+			assert.Nil(t, plist.Validate())
+
+			out, err := plist.Build()
+			assert.Nil(t, err)
+			assert.NotContains(t, out, "$(")
+
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+
+			assert.Equal(t, tt.bundleID, plist.Resolve().BundleID())
+		})
+	}
+}
+
+func TestPropertyList_BuildSettingsReport(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("$(PRODUCT_NAME)")
+	plist.BuildSettings(xcconfig.Settings{
+		"DEVELOPMENT_LANGUAGE":      "en",
+		"PRODUCT_NAME":              "Best App",
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.best.$(PRODUCT_NAME:c99extidentifier)",
+		"EXECUTABLE_NAME":           "",
+		"MACOSX_DEPLOYMENT_TARGET":  "10.15",
+	})
+
+	issues := plist.Report().Errors()
+	assert.Len(t, issues, 3)
+
+	assert.Equal(t, "ExecutableFile", issues[0].Path)
+	assert.Equal(t, CodeMissingProperty, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], ErrMissingRequiredProperty))
+
+	assert.Equal(t, "BundleID", issues[1].Path)
+	assert.Equal(t, CodeInvalidBundleID, issues[1].Code)
+	assert.True(t, errors.Is(issues[1], ErrInvalidBundleID))
+
+	assert.Equal(t, keyCFBundleShortVersionString, issues[2].Key)
+	assert.Equal(t, CodeUnresolvedVariable, issues[2].Code)
+	assert.Equal(t, "Unresolved build setting: $(MARKETING_VERSION) (CFBundleShortVersionString)", issues[2].Error())
+
+	_, err := plist.Build()
+	assert.True(t, errors.Is(err, ErrUnresolvedVariable))
+}
+
+func TestPropertyList_BundleIDCharacters(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	assert.Nil(t, plist.Validate())
+
+	plist.BundleID("com.best_app")
+	err := plist.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidBundleID))
+}
+
+func TestPropertyList_ConfigurationBuildSettings(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("$(PRODUCT_NAME)")
+	plist.BuildSettings(xcconfig.Settings{
+		"DEVELOPMENT_LANGUAGE":      "en",
+		"PRODUCT_NAME":              "Best App",
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.best.$(PRODUCT_NAME:rfc1034identifier:lower)",
+		"EXECUTABLE_NAME":           "$(PRODUCT_NAME)",
+		"MARKETING_VERSION":         "1.2.0",
+		"MACOSX_DEPLOYMENT_TARGET":  "10.15",
+	})
+	plist.Configuration("Debug", func(p *PropertyList) {
+		p.BuildSettings(xcconfig.Settings{
+			"DEVELOPMENT_LANGUAGE":      "en",
+			"PRODUCT_NAME":              "Best App",
+			"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app.debug",
+			"EXECUTABLE_NAME":           "$(PRODUCT_NAME)",
+			"MARKETING_VERSION":         "1.2.0",
+			"MACOSX_DEPLOYMENT_TARGET":  "10.15",
+		})
+	})
+	plist.Configuration("Release", func(p *PropertyList) {
+		p.BuildSettings(xcconfig.Settings{"PRODUCT_NAME": "Best App"})
+	})
+	plist.Configuration("Staging", func(p *PropertyList) {})
+
+	debug, err := plist.BuildConfiguration("Debug")
+	assert.Nil(t, err)
+	assert.Contains(t, debug, "<key>CFBundleIdentifier</key><string>com.best.app.debug</string>")
+
+	staging, err := plist.BuildConfiguration("Staging")
+	assert.Nil(t, err)
+	assert.Contains(t, staging, "<key>CFBundleIdentifier</key><string>com.best.best-app</string>")
+
+	report, err := plist.ConfigurationReport("Release")
+	assert.Nil(t, err)
+
+	issues := report.Errors()
+	assert.NotEmpty(t, issues)
+	for _, issue := range issues {
+		assert.Equal(t, "Configurations[Release]", issue.Path)
+		assert.Equal(t, CodeUnresolvedVariable, issue.Code)
+	}
+}
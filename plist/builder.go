@@ -1,19 +1,30 @@
 package plist
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"sync"
 
-	"howett.net/plist"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/illyabusigin/apptools/xcconfig"
+)
+
+// Validation codes reported by the property list builder.
+const (
+	CodeMissingProperty   = validation.CodeMissingProperty
+	CodeForbiddenProperty = "forbidden-property"
 )
 
 var (
 	// ErrMissingRequiredProperty is the error returned for missing properties
-	ErrMissingRequiredProperty = errors.New("Missing property")
-	errMissingProperty         = func(p string) error {
+	ErrMissingRequiredProperty = validation.ErrMissingRequiredProperty
+
+	// ErrForbiddenProperty is the error returned for properties that are not
+	// supported on the targeted platform
+	ErrForbiddenProperty = errors.New("Property is not supported")
+	errMissingProperty   = func(p string) error {
 		return fmt.Errorf("%w: %v", ErrMissingRequiredProperty, p)
 	}
 )
@@ -40,7 +51,19 @@ type PropertyList struct {
 	launchStoryboardName    string
 	mainStoryboardName      string
 
-	viewControllerBasedStatusBarAppearance bool
+	minimumSystemVersion string
+	principalClass       string
+	applicationCategory  ApplicationCategory
+	copyright            string
+	mainNibFile          string
+
+	watchApplication             bool
+	companionAppBundleIdentifier string
+	runsIndependently            *bool
+
+	supportsHDR bool
+
+	viewControllerBasedStatusBarAppearance *bool
 
 	statusBarStyle     string
 	statusBarHidden    bool
@@ -53,13 +76,28 @@ type PropertyList struct {
 	privacy            *Privacy
 	capabilities       *DeviceCapabilities
 	scene              *SceneManifest
+	extension          *Extension
+	urlTypes           []*URLType
+	documentTypes      []*DocumentType
+	exportedTypes      []*TypeDeclaration
+	importedTypes      []*TypeDeclaration
+	backgroundModes    *BackgroundModes
+	backgroundTasks    []string
+	queriesSchemes     []string
 
-	skipValidation bool
+	localizedDisplayNames map[string]string
 
-	custom map[string]interface{}
-	once   sync.Once
+	configurations []configuration
+	touched        map[string]bool
 
-	data map[string]interface{}
+	buildSettings xcconfig.Settings
+
+	skipValidation bool
+	format         format.Format
+
+	custom  map[string]interface{}
+	removed map[string]bool
+	once    sync.Once
 }
 
 func (p *PropertyList) init() {
@@ -81,8 +119,8 @@ func New(platform Platform) *PropertyList {
 	return &p
 }
 
-// Defaults specifies a set of property list defaults for mobile devices.
-// A list of the specified defaults can be found below:
+// Defaults specifies a set of property list defaults for the property list's
+// platform. A list of the specified defaults for iOS can be found below:
 //  plist.DevelopmentRegion("$(DEVELOPMENT_LANGUAGE)")
 //  plist.BundleID("$(PRODUCT_BUNDLE_IDENTIFIER)")
 //  plist.ExecutableFile("$(EXECUTABLE_NAME)")
@@ -97,6 +135,26 @@ func New(platform Platform) *PropertyList {
 //  plist.AppTransportSecurity(func(s *AppTransportSecurity) {
 // 	 s.AllowArbitraryLoads(true)
 //  })
+//
+// On macOS the iOS specific defaults are replaced with:
+//  plist.MinimumSystemVersion("$(MACOSX_DEPLOYMENT_TARGET)")
+//  plist.PrincipalClass("NSApplication")
+//
+// On watchOS:
+//  plist.WatchApplication(true)
+//
+// On tvOS:
+//  plist.RequiresIOS()
+//  plist.MainStoryboard("Main")
+//  plist.Capabilities(func(c *DeviceCapabilities) {
+// 	 c.ARM64()
+//  })
+//
+// On visionOS:
+//  plist.SceneManifest(func(m *SceneManifest) {
+// 	 m.MultipleWindows(true)
+// 	 m.PreferredDefaultSessionRole(SceneSessionRoleApplication)
+//  })
 func (p *PropertyList) Defaults() {
 	p.DevelopmentRegion("$(DEVELOPMENT_LANGUAGE)")
 	p.BundleID("$(PRODUCT_BUNDLE_IDENTIFIER)")
@@ -105,21 +163,10 @@ func (p *PropertyList) Defaults() {
 	p.PackageType("APPLE")
 	p.VersionShort("$(MARKETING_VERSION)")
 	p.Version("1")
-	p.RequiresIOS()
-	p.MainStoryboard("Main")
-	p.ViewControllerBasedStatusBarAppearance(true)
-	p.StatusBarStyleDefault()
-	p.StatusBarHidden(false)
-	p.Capabilities(func(c *DeviceCapabilities) {
-		c.ARMv7()
-	})
-	p.AppTransportSecurity(func(s *AppTransportSecurity) {
-		s.AllowArbitraryLoads(true)
-	})
-	p.Orientations(func(o *Orientations) {
-		o.Portrait()
-	})
-	// TODO: Scene manifest struct?
+
+	if defaults := p.rules().defaults; defaults != nil {
+		defaults(p)
+	}
 }
 
 // SkipValidation will skip validation of required fields.
@@ -128,78 +175,106 @@ func (p *PropertyList) SkipValidation() *PropertyList {
 	return p
 }
 
+// Format specifies the output format used when building the property list,
+// i.e. `format.PrettyXML` for XML indented the way Xcode writes it. Defaults
+// to single-line XML.
+func (p *PropertyList) Format(f format.Format) *PropertyList {
+	p.format = f
+	return p
+}
+
 // Validate will validate the specified propery list configuration and return
-// relevant and detailed errors for any issues discovered.
+// relevant and detailed errors for any issues discovered. The returned error
+// is a `*validation.Report` containing every error found.
 func (p *PropertyList) Validate() error {
-	if p.bundleIdentifier == "" {
-		return errMissingProperty(fmt.Sprintf("BundleID (CFBundleIdentifier)"))
-	}
-
-	if p.bundleName == "" {
-		return errMissingProperty(fmt.Sprintf("BundleName (CFBundleName)"))
-	}
-
-	if p.statusBarStyle == "" {
-		return errMissingProperty(fmt.Sprintf("StatusBarStyle (UIStatusBarStyle)"))
-	}
+	return p.Report().Err()
+}
 
-	if p.displayName == "" {
-		return errMissingProperty(fmt.Sprintf("DisplayName (CFBundleDisplayName)"))
-	}
+// Report will validate the specified property list configuration and return a
+// report containing every issue discovered, including warnings.
+func (p *PropertyList) Report() *validation.Report {
+	r := &validation.Report{}
 
-	if p.developmentRegion == "" {
-		return errMissingProperty(fmt.Sprintf("DevelopmentRegion (CFBundleDevelopmentRegion)"))
-	}
+	rules := p.rules()
 
-	if p.executableFile == "" {
-		return errMissingProperty(fmt.Sprintf("ExecutableFile (CFBundleExecutable)"))
+	required := rules.required
+	if p.extension == nil {
+		required = properties(required, rules.appRequired)
 	}
 
-	if p.version == "" {
-		return errMissingProperty(fmt.Sprintf("InfoDictionaryVersion (CFBundleInfoDictionaryVersion)"))
-	}
+	data, unresolved := p.expand()
 
-	if p.packageType == "" {
-		return errMissingProperty(fmt.Sprintf("PackageType (CFBundlePackageType)"))
+	for _, property := range required {
+		if property.missing(p) {
+			r.AddError(property.path, CodeMissingProperty, property.key, ErrMissingRequiredProperty)
+		} else if value, ok := data[property.key].(string); ok && value == "" && p.buildSettings != nil {
+			r.AddError(property.path, CodeMissingProperty, property.key,
+				fmt.Errorf("%w: expands to an empty value", ErrMissingRequiredProperty))
+		}
 	}
 
-	if p.applicationVersionShort == "" {
-		return errMissingProperty(fmt.Sprintf("VersionShort (CFBundleShortVersionString)"))
-	}
+	for _, property := range rules.forbidden {
+		found := false
+		if property.set != nil {
+			found = property.set(p)
+		} else {
+			_, found = data[property.key]
+		}
 
-	if p.bundleVersion == "" {
-		return errMissingProperty(fmt.Sprintf("Version (CFBundleVersion)"))
+		if found {
+			r.AddError(property.path, CodeForbiddenProperty, property.key,
+				fmt.Errorf("%w on %v", ErrForbiddenProperty, rules.name))
+		}
 	}
 
-	if p.ats == nil {
-		return errMissingProperty(fmt.Sprintf("AppTransportSecurity (NSAppTransportSecurity)"))
+	if scene := p.scene; scene != nil {
+		r.Merge("SceneManifest", scene.Report())
 	}
 
-	if p.statusBarStyle == "" {
-		return errMissingProperty(fmt.Sprintf("StatusBarStyle (UIStatusBarStyle)"))
+	if extension := p.extension; extension != nil {
+		r.Merge("Extension", extension.Report())
 	}
 
-	if p.scene == nil {
-		return errMissingProperty(fmt.Sprintf("SceneManifest (UIApplicationSceneManifest)"))
-	}
+	r.Merge("", urlTypesReport(p.urlTypes))
+	r.Merge("", documentTypesReport(p.documentTypes, p.exportedTypes, p.importedTypes))
+	r.Merge("", p.backgroundReport())
+	r.Merge("", p.localizationReport())
+	r.Merge("", p.privacyReport())
+	r.Merge("", versionReport(data))
+	r.Merge("", bundleIDReport(data))
+	r.Merge("", buildSettingsReport(unresolved))
 
-	return nil
+	return r
 }
 
 // DisplayName specifies the user-visible name of the bundle; used by Siri and
-// visible on the Home screen in iOS.
+// visible on the Home screen in iOS. The name can be translated for other
+// locales, i.e. `DisplayName("Best App", Translate("fr", "Meilleure App"))`,
+// see `SaveLocalizations`.
 // See https://developer.apple.com/library/archive/documentation/General/Reference/InfoPlistKeyReference/Articles/CoreFoundationKeys.html#//apple_ref/doc/uid/20001431-110725 for details.
-func (p *PropertyList) DisplayName(n string) *PropertyList {
+func (p *PropertyList) DisplayName(n string, translations ...Translation) *PropertyList {
+	p.touch(keyCFBundleDisplayName)
 	p.displayName = n
+	p.localizedDisplayNames = nil
+
+	for _, t := range translations {
+		if p.localizedDisplayNames == nil {
+			p.localizedDisplayNames = map[string]string{}
+		}
+
+		p.localizedDisplayNames[t.Locale] = t.Value
+	}
+
 	return p
 }
 
 // BundleID specifies an identifier string that specifies the app type of the
-// bundle. The string should be in reverse DNS format using only the Roman
+// bundle. The string must be in reverse DNS format using only the Roman
 // alphabet in upper and lower case (A–Z, a–z), the dot (“.”), and the hyphen
-// (“-”).
+// (“-”), unless it is a build setting reference.
 // See https://developer.apple.com/library/archive/documentation/General/Reference/InfoPlistKeyReference/Articles/CoreFoundationKeys.html#//apple_ref/doc/uid/20001431-102070 for details.
 func (p *PropertyList) BundleID(id string) *PropertyList {
+	p.touch(keyCFBundleIdentifier)
 	p.bundleIdentifier = id
 	return p
 }
@@ -208,6 +283,7 @@ func (p *PropertyList) BundleID(id string) *PropertyList {
 // as a language ID.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledevelopmentregion for more information.
 func (p *PropertyList) DevelopmentRegion(v string) *PropertyList {
+	p.touch(keyCFBundleDevelopmentRegion)
 	p.developmentRegion = v
 	return p
 }
@@ -215,6 +291,7 @@ func (p *PropertyList) DevelopmentRegion(v string) *PropertyList {
 // ExecutableFile specifies the name of the bundle’s executable file.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleExecutable for more information.
 func (p *PropertyList) ExecutableFile(v string) *PropertyList {
+	p.touch(keyCFBundleExecutable)
 	p.executableFile = v
 	return p
 }
@@ -222,6 +299,7 @@ func (p *PropertyList) ExecutableFile(v string) *PropertyList {
 // InfoDictionaryVersion sets the current version of the Information Property List structure.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleInfoDictionaryVersion for more information.
 func (p *PropertyList) InfoDictionaryVersion(v string) *PropertyList {
+	p.touch(keyCFBundleInfoDictionaryVersion)
 	p.version = v
 	return p
 }
@@ -229,6 +307,7 @@ func (p *PropertyList) InfoDictionaryVersion(v string) *PropertyList {
 // BundleName specifies a user-visible short name for the bundle.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleName for more information.
 func (p *PropertyList) BundleName(v string) *PropertyList {
+	p.touch(keyCFBundleName)
 	p.bundleName = v
 	return p
 }
@@ -236,21 +315,27 @@ func (p *PropertyList) BundleName(v string) *PropertyList {
 // PackageType specifies the type of bundle.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundlePackageType for more information.
 func (p *PropertyList) PackageType(v string) *PropertyList {
+	p.touch(keyCFBundlePackageType)
 	p.packageType = v
 	return p
 }
 
-// VersionShort specifies the release or version number of the bundle.
+// VersionShort specifies the release or version number of the bundle. Must be
+// one to three period-separated integers, i.e. `1.2.3`, unless it is a build
+// setting reference.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleShortVersionString for more information.
 func (p *PropertyList) VersionShort(v string) *PropertyList {
+	p.touch(keyCFBundleShortVersionString)
 	p.applicationVersionShort = v
 	return p
 }
 
 // Version specifies the version of the build that identifies an iteration of
-// the bundle. Should follow semantic versioning.
+// the bundle. Must be one to three period-separated integers, i.e. `42` or
+// `1.2.3`, unless it is a build setting reference.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleVersion for more information.
 func (p *PropertyList) Version(v string) *PropertyList {
+	p.touch(keyCFBundleVersion)
 	p.bundleVersion = v
 	return p
 }
@@ -258,6 +343,7 @@ func (p *PropertyList) Version(v string) *PropertyList {
 // RequiresIOS specifies a true boolean value indicating whether the app must run in iOS.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/LSRequiresIPhoneOS for more information.
 func (p *PropertyList) RequiresIOS() *PropertyList {
+	p.touch(keyLSRequiresIPhoneOS)
 	p.requiresIphoneEnv = true
 	return p
 }
@@ -265,6 +351,7 @@ func (p *PropertyList) RequiresIOS() *PropertyList {
 // StatusBarStyleDefault uses a dark status bar, intended for use on light
 // backgrounds.
 func (p *PropertyList) StatusBarStyleDefault() *PropertyList {
+	p.touch(keyUIStatusBarStyle)
 	p.statusBarStyle = "UIStatusBarStyleDefault"
 	return p
 }
@@ -272,6 +359,7 @@ func (p *PropertyList) StatusBarStyleDefault() *PropertyList {
 // StatusBarStyleLightContent uses a light status bar, intended for use on dark
 // backgrounds.
 func (p *PropertyList) StatusBarStyleLightContent() *PropertyList {
+	p.touch(keyUIStatusBarStyle)
 	p.statusBarStyle = "UIStatusBarStyleLightContent"
 	return p
 }
@@ -279,6 +367,7 @@ func (p *PropertyList) StatusBarStyleLightContent() *PropertyList {
 // StatusBarStyleDarkContent uses a light status bar, intended for use on light
 // backgrounds.
 func (p *PropertyList) StatusBarStyleDarkContent() *PropertyList {
+	p.touch(keyUIStatusBarStyle)
 	p.statusBarStyle = "UIStatusBarStyleDarkContent"
 	return p
 }
@@ -286,6 +375,7 @@ func (p *PropertyList) StatusBarStyleDarkContent() *PropertyList {
 // StatusBarHidden specifies a boolean value indicating whether the status bar
 // is initially hidden when the app launches.
 func (p *PropertyList) StatusBarHidden(v bool) *PropertyList {
+	p.touch(keyUIStatusBarHidden)
 	p.statusBarHidden = v
 	return p
 }
@@ -295,13 +385,15 @@ func (p *PropertyList) StatusBarHidden(v bool) *PropertyList {
 // current view controller.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/uiviewcontrollerbasedstatusbarappearance for more information.
 func (p *PropertyList) ViewControllerBasedStatusBarAppearance(v bool) *PropertyList {
-	p.viewControllerBasedStatusBarAppearance = true
+	p.touch(keyUIViewControllerBasedStatusBarAppearance)
+	p.viewControllerBasedStatusBarAppearance = &v
 	return p
 }
 
 // LaunchScreenStoryboard specifies the  filename of the storyboard from which
 // to generate the app’s launch image.
 func (p *PropertyList) LaunchScreenStoryboard(storyboard string) *PropertyList {
+	p.touch(keyUILaunchStoryboardName)
 	p.launchStoryboardName = storyboard
 	return p
 }
@@ -309,20 +401,34 @@ func (p *PropertyList) LaunchScreenStoryboard(storyboard string) *PropertyList {
 // MainStoryboard specifies the name of the app's main storyboard file.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/uimainstoryboardfile for more information.
 func (p *PropertyList) MainStoryboard(storyboard string) *PropertyList {
+	p.touch(keyUIMainStoryboardFile)
 	p.mainStoryboardName = storyboard
 	return p
 }
 
 // SceneManifest specifies the scene manifest for the application.
 func (p *PropertyList) SceneManifest(f func(m *SceneManifest)) *PropertyList {
+	p.touch(keyUIApplicationSceneManifest)
 	p.scene = &SceneManifest{}
 	f(p.scene)
 	return p
 }
 
+// Extension specifies the `NSExtension` dictionary of an app extension. App
+// extensions are not required to specify app only properties such as the
+// scene manifest.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension for more information.
+func (p *PropertyList) Extension(f func(e *Extension)) *PropertyList {
+	p.touch(keyNSExtension)
+	p.extension = &Extension{}
+	f(p.extension)
+	return p
+}
+
 // AppTransportSecurity allows you to specify App Transport Security (ATS).
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/NSAppTransportSecurity for more information.
 func (p *PropertyList) AppTransportSecurity(f func(s *AppTransportSecurity)) *PropertyList {
+	p.touch(keyNSAppTransportSecurity)
 	p.ats = &AppTransportSecurity{}
 	f(p.ats)
 	return p
@@ -331,6 +437,7 @@ func (p *PropertyList) AppTransportSecurity(f func(s *AppTransportSecurity)) *Pr
 // Orientations allows you to specify the initial orientation of the app’s user
 // interface.
 func (p *PropertyList) Orientations(f func(o *Orientations)) *PropertyList {
+	p.touch(keyUISupportedInterfaceOrientations)
 	p.orientation = &Orientations{}
 	f(p.orientation)
 	return p
@@ -339,6 +446,7 @@ func (p *PropertyList) Orientations(f func(o *Orientations)) *PropertyList {
 // TabletOrientations allows you to specify the initial orientation of the app’s
 // user interface when running on an iPad.
 func (p *PropertyList) TabletOrientations(f func(o *Orientations)) *PropertyList {
+	p.touch(keyUISupportedInterfaceOrientationsIPad)
 	p.tabletOrientations = &Orientations{}
 	f(p.tabletOrientations)
 	return p
@@ -349,6 +457,11 @@ func (p *PropertyList) TabletOrientations(f func(o *Orientations)) *PropertyList
 func (p *PropertyList) Privacy(f func(p *Privacy)) *PropertyList {
 	p.privacy = &Privacy{}
 	f(p.privacy)
+
+	for key := range p.privacy.values {
+		p.touch(key)
+	}
+
 	return p
 }
 
@@ -356,6 +469,7 @@ func (p *PropertyList) Privacy(f func(p *Privacy)) *PropertyList {
 // to run. This is a required field.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/uirequireddevicecapabilities for more information.
 func (p *PropertyList) Capabilities(f func(c *DeviceCapabilities)) *PropertyList {
+	p.touch(keyUIRequiredDeviceCapabilities)
 	p.capabilities = &DeviceCapabilities{}
 	f(p.capabilities)
 	return p
@@ -364,13 +478,33 @@ func (p *PropertyList) Capabilities(f func(c *DeviceCapabilities)) *PropertyList
 // Set will set an arbitrary key-value pair in the Info.plist. Key set in this
 // manner will override any keys set by any of the builder functions.
 func (p *PropertyList) Set(key string, value interface{}) *PropertyList {
+	p.touch(key)
 	p.init()
 
+	delete(p.removed, key)
 	p.custom[key] = value
 
 	return p
 }
 
+// Remove will remove keys from the Info.plist, including keys set by any of
+// the builder functions. In a configuration overlay the keys are removed from
+// the base property list.
+func (p *PropertyList) Remove(keys ...string) *PropertyList {
+	p.init()
+
+	if p.removed == nil {
+		p.removed = map[string]bool{}
+	}
+
+	for _, key := range keys {
+		delete(p.custom, key)
+		p.removed[key] = true
+	}
+
+	return p
+}
+
 // Build will build the Info.plist
 func (p *PropertyList) Build() (string, error) {
 	if !p.skipValidation {
@@ -379,11 +513,39 @@ func (p *PropertyList) Build() (string, error) {
 		}
 	}
 
-	buf := bytes.Buffer{}
+	out, err := format.Encode(p.build(), p.format)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// build computes the property list dictionary, expanding any build settings.
+func (p *PropertyList) build() map[string]interface{} {
+	data, _ := p.expand()
+
+	return data
+}
+
+// expand computes the property list dictionary, expanding any build settings,
+// along with the unresolved build setting references of each key.
+func (p *PropertyList) expand() (map[string]interface{}, map[string][]string) {
+	data := p.buildData()
+
+	if p.buildSettings == nil {
+		return data, nil
+	}
+
+	return expandBuildSettings(p.buildSettings, data)
+}
 
-	p.data = map[string]interface{}{
+// buildData computes the property list dictionary without expanding build
+// settings. Each section applies its keys to the dictionary, leaving the
+// builder unchanged.
+func (p *PropertyList) buildData() map[string]interface{} {
+	data := map[string]interface{}{
 		keyCFBundleIdentifier:            p.bundleIdentifier,
-		keyCFBundleDisplayName:           p.displayName,
 		keyCFBundleDevelopmentRegion:     p.developmentRegion,
 		keyCFBundleExecutable:            p.executableFile,
 		keyCFBundleInfoDictionaryVersion: p.version,
@@ -391,50 +553,86 @@ func (p *PropertyList) Build() (string, error) {
 		keyCFBundlePackageType:           p.packageType,
 		keyCFBundleShortVersionString:    p.applicationVersionShort,
 		keyCFBundleVersion:               p.bundleVersion,
-		keyLSRequiresIPhoneOS:            p.requiresIphoneEnv,
-		keyUIStatusBarHidden:             p.statusBarHidden,
-		keyUIStatusBarStyle:              p.statusBarStyle,
 	}
 
+	rules := p.rules()
+
+	if p.displayName != "" || rules.displayName {
+		data[keyCFBundleDisplayName] = p.displayName
+	}
+
+	// Platforms that don't use the following keys only include them when they
+	// have been explicitly specified so they can be reported as forbidden.
+	if p.requiresIphoneEnv || rules.requiresIPhoneOS {
+		data[keyLSRequiresIPhoneOS] = p.requiresIphoneEnv
+	}
+
+	if p.statusBarHidden || rules.statusBar {
+		data[keyUIStatusBarHidden] = p.statusBarHidden
+	}
+
+	if p.statusBarStyle != "" || rules.statusBar {
+		data[keyUIStatusBarStyle] = p.statusBarStyle
+	}
+
+	if p.viewControllerBasedStatusBarAppearance != nil {
+		data[keyUIViewControllerBasedStatusBarAppearance] = *p.viewControllerBasedStatusBarAppearance
+	}
+
+	if p.launchStoryboardName != "" {
+		data[keyUILaunchStoryboardName] = p.launchStoryboardName
+	}
+
+	if p.mainStoryboardName != "" {
+		data[keyUIMainStoryboardFile] = p.mainStoryboardName
+	}
+
+	p.applyMac(data)
+	p.applyWatch(data)
+	p.applyTV(data)
+
 	if ats := p.ats; ats != nil {
-		ats.Apply(p)
+		ats.Apply(data)
 	}
 
 	if orientations := p.orientation; orientations != nil {
-		orientations.Apply(p, "")
+		orientations.Apply(data, "")
 	}
 
 	if tabletOrientations := p.tabletOrientations; tabletOrientations != nil {
-		tabletOrientations.Apply(p, "~ipad")
-	}
-
-	if privacy := p.privacy; privacy != nil {
-		privacy.Apply(p)
+		tabletOrientations.Apply(data, "~ipad")
 	}
 
 	if privacy := p.privacy; privacy != nil {
-		privacy.Apply(p)
+		privacy.Apply(data)
 	}
 
 	if capabilities := p.capabilities; capabilities != nil {
-		capabilities.Apply(p)
+		capabilities.Apply(data)
 	}
 
 	if scene := p.scene; scene != nil {
-		scene.Apply(p)
+		scene.Apply(data)
+	}
+
+	if extension := p.extension; extension != nil {
+		extension.Apply(data)
 	}
 
+	p.applyURLTypes(data)
+	p.applyDocumentTypes(data)
+	p.applyBackground(data)
+
 	// Custom keys are always applied last, overriding any builder keys
 	for key, value := range p.custom {
-		p.data[key] = value
+		data[key] = value
 	}
 
-	encoder := plist.NewEncoder(&buf)
-	if err := encoder.Encode(p.data); err != nil {
-		return "", err
+	for key := range p.removed {
+		delete(data, key)
 	}
 
-	return buf.String(), nil
+	return data
 }
 
 // Write the property list to the specified io.Writer.
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/illyabusigin/apptools/entitlements"
+	"github.com/illyabusigin/apptools/format"
 	assert "github.com/stretchr/testify/require"
 )
 
@@ -223,3 +225,90 @@ func TestPropertyList_Properties(t *testing.T) {
 		})
 	}
 }
+
+func TestErrMissingRequiredProperty_Shared(t *testing.T) {
+	e := entitlements.New()
+	e.ICloud.CloudKit()
+
+	assert.True(t, errors.Is(e.Validate(), ErrMissingRequiredProperty), "Missing properties should match across packages")
+}
+
+func TestPropertyList_Report(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Defaults()
+	plist.BundleID("")
+
+	report := plist.Report()
+	assert.True(t, report.HasErrors())
+
+	keys := []string{}
+	for _, issue := range report.Errors() {
+		assert.Equal(t, CodeMissingProperty, issue.Code)
+		keys = append(keys, issue.Key)
+	}
+
+	assert.Equal(t, []string{
+		"CFBundleIdentifier",
+		"CFBundleName",
+		"CFBundleDisplayName",
+		"UIApplicationSceneManifest",
+	}, keys, "Every missing property should be reported at once")
+
+	plist.SceneManifest(func(m *SceneManifest) {
+		m.Application(func(c *SceneConfiguration) {})
+	})
+
+	report = plist.Report()
+	assert.Equal(t, "SceneManifest.Application.Name", report.Errors()[len(report.Errors())-1].Path)
+}
+
+func TestPropertyList_ReportLeavesBuilderUnchanged(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("Best App")
+	plist.AppTransportSecurity(func(s *AppTransportSecurity) {
+		s.AllowArbitraryLoads(true)
+	})
+
+	before, err := plist.Build()
+	assert.Nil(t, err)
+
+	plist.Report()
+	assert.Empty(t, plist.custom, "Building should not store sections as custom keys")
+
+	after, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, before, after)
+}
+
+func TestPropertyList_Format(t *testing.T) {
+	build := func(f format.Format) string {
+		plist := New(PlatformMac)
+		plist.Defaults()
+		plist.BundleName("Best App")
+		plist.VersionShort("1.2.3")
+		plist.Version("42")
+		plist.Format(f)
+		plist.Set("BestCustomKey", []string{"b", "a"})
+
+		out, err := plist.Build()
+		assert.Nil(t, err)
+
+		return out
+	}
+
+	out := build(format.PrettyXML)
+	assert.Contains(t, out, "<dict>\n\t<key>BestCustomKey</key>\n\t<array>\n\t\t<string>b</string>\n\t\t<string>a</string>\n\t</array>\n")
+	assert.Contains(t, out, "\t<key>CFBundleVersion</key>\n\t<string>42</string>\n")
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, out, build(format.PrettyXML), "Output should be byte-stable across runs")
+	}
+
+	values, err := Decode([]byte(build(format.Binary)))
+	assert.Nil(t, err)
+	assert.Equal(t, "1.2.3", values.VersionShort())
+
+	assert.Contains(t, build(format.OpenStep), "CFBundleShortVersionString = \"1.2.3\";")
+	assert.Contains(t, build(format.JSON), "\"CFBundleVersion\": \"42\"")
+}
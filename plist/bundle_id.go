@@ -0,0 +1,184 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/illyabusigin/apptools/entitlements"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+	"howett.net/plist"
+)
+
+// Validation codes reported for bundle identifiers.
+const (
+	CodeInvalidBundleID  = "invalid-bundle-id"
+	CodeBundleIDMismatch = "bundle-id-mismatch"
+)
+
+var (
+	// ErrInvalidBundleID is the error returned for bundle identifiers
+	// containing characters other than letters, digits, '.' and '-'.
+	ErrInvalidBundleID = errors.New("Bundle identifiers may only contain letters, digits, '.' and '-'")
+
+	// ErrNotReverseDNS is the error returned for bundle identifiers that are
+	// not in reverse DNS format, i.e. `com.best.app`.
+	ErrNotReverseDNS = errors.New("Bundle identifiers must be in reverse DNS format")
+
+	// ErrHostBundleIDPrefix is the error returned for app extensions and
+	// watchOS apps whose bundle identifier is not prefixed by the bundle
+	// identifier of the host app.
+	ErrHostBundleIDPrefix = errors.New("Bundle identifier must be prefixed by the host app's bundle identifier")
+
+	// ErrCompanionBundleID is the error returned when the watchOS app's
+	// `WKCompanionAppBundleIdentifier` is not the bundle identifier of the
+	// companion iOS app.
+	ErrCompanionBundleID = errors.New("Companion app bundle identifier must match the companion app's bundle identifier")
+
+	// ErrApplicationIdentifierMismatch is the error returned when the
+	// `application-identifier` entitlement does not match the bundle
+	// identifier.
+	ErrApplicationIdentifierMismatch = errors.New("Application identifier does not match the bundle identifier")
+
+	bundleIDCharactersRegex = regexp.MustCompile(`^[A-Za-z0-9.\-]+$`)
+	reverseDNSRegex         = regexp.MustCompile(`^[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)+$`)
+
+	// teamPrefixRegex matches the prefix of an application identifier, i.e.
+	// `ABCDE12345.` or `$(AppIdentifierPrefix)`.
+	teamPrefixRegex = regexp.MustCompile(`^([A-Z0-9]{10}\.|\$\(AppIdentifierPrefix\)|\$\(TeamIdentifierPrefix\))`)
+)
+
+// resolved returns true if the value no longer contains any build setting
+// references.
+func resolved(v string) bool {
+	return !strings.Contains(v, "$")
+}
+
+// validBundleID returns the error found in a bundle identifier without any
+// build setting references.
+func validBundleID(id string) error {
+	switch {
+	case !bundleIDCharactersRegex.MatchString(id):
+		return fmt.Errorf("%w: %q", ErrInvalidBundleID, id)
+	case !reverseDNSRegex.MatchString(id):
+		return fmt.Errorf("%w: %q", ErrNotReverseDNS, id)
+	}
+
+	return nil
+}
+
+// hasBundleIDPrefix returns true if the bundle identifier is nested under the
+// host bundle identifier, i.e. `com.best.app.widget` under `com.best.app`.
+func hasBundleIDPrefix(id, host string) bool {
+	return strings.HasPrefix(id, host+".")
+}
+
+// bundleIDReport validates the format of the bundle identifiers that are not
+// build setting references and checks that a watchOS app is nested under its
+// companion app.
+func bundleIDReport(data map[string]interface{}) *validation.Report {
+	r := &validation.Report{}
+
+	id, _ := data[keyCFBundleIdentifier].(string)
+	if id != "" && resolved(id) {
+		if err := validBundleID(id); err != nil {
+			r.AddError("BundleID", CodeInvalidBundleID, keyCFBundleIdentifier, err)
+		}
+	}
+
+	companion, _ := data[keyWKCompanionAppBundleIdentifier].(string)
+	if companion != "" && resolved(companion) {
+		if err := validBundleID(companion); err != nil {
+			r.AddError("CompanionAppBundleID", CodeInvalidBundleID, keyWKCompanionAppBundleIdentifier, err)
+		} else if id != "" && resolved(id) && !hasBundleIDPrefix(id, companion) {
+			r.AddError("BundleID", CodeBundleIDMismatch, keyCFBundleIdentifier,
+				fmt.Errorf("%w: %v is not nested under %v", ErrHostBundleIDPrefix, id, companion))
+		}
+	}
+
+	return r
+}
+
+// HostReport checks the relationship between an app extension or watchOS app
+// and the app hosting it. The bundle identifier must be prefixed by the bundle
+// identifier of the host app, and a watchOS app's
+// `WKCompanionAppBundleIdentifier` must match it. Bundle identifiers that
+// contain unresolved build setting references are not checked.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/wkcompanionappbundleidentifier for more information.
+func (p *PropertyList) HostReport(host *PropertyList) *validation.Report {
+	r := &validation.Report{}
+
+	values, hostID := p.Resolve(), host.Resolve().BundleID()
+	if hostID == "" || !resolved(hostID) {
+		return r
+	}
+
+	if id := values.BundleID(); id != "" && resolved(id) && !hasBundleIDPrefix(id, hostID) {
+		r.AddError("BundleID", CodeBundleIDMismatch, keyCFBundleIdentifier,
+			fmt.Errorf("%w: %v is not nested under %v", ErrHostBundleIDPrefix, id, hostID))
+	}
+
+	if companion := values.stringValue(keyWKCompanionAppBundleIdentifier); companion != "" && resolved(companion) && companion != hostID {
+		r.AddError("CompanionAppBundleID", CodeBundleIDMismatch, keyWKCompanionAppBundleIdentifier,
+			fmt.Errorf("%w: %v is not %v", ErrCompanionBundleID, companion, hostID))
+	}
+
+	return r
+}
+
+// CheckEntitlements builds the entitlements and checks that the
+// `application-identifier` entitlement, when specified, matches the bundle
+// identifier. The entitlements output format is ignored.
+func (p *PropertyList) CheckEntitlements(e *entitlements.Entitlements) (*validation.Report, error) {
+	xml := *e
+	xml.Format(format.XML)
+
+	data, err := xml.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.CompareEntitlements([]byte(data))
+}
+
+// CompareEntitlements decodes an entitlements property list and checks that
+// the `application-identifier` entitlement, when specified, matches the bundle
+// identifier. Values that contain unresolved build setting references are
+// reported as warnings.
+func (p *PropertyList) CompareEntitlements(data []byte) (*validation.Report, error) {
+	values := map[string]interface{}{}
+	if _, err := plist.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	r := &validation.Report{}
+
+	id := p.Resolve().BundleID()
+
+	for _, key := range []string{"application-identifier", "com.apple.application-identifier"} {
+		appID, ok := values[key].(string)
+		if !ok {
+			continue
+		}
+
+		suffix := teamPrefixRegex.ReplaceAllString(appID, "")
+		suffix = strings.Replace(suffix, "$(CFBundleIdentifier)", id, -1)
+		if p.buildSettings != nil {
+			suffix, _ = p.buildSettings.Expand(suffix)
+		}
+
+		switch {
+		case suffix == id:
+		case !resolved(suffix) || !resolved(id):
+			r.AddWarning("BundleID", CodeUnresolvedVariable, key,
+				fmt.Errorf("%w: %v cannot be compared to %v", ErrUnresolvedVariable, appID, id))
+		default:
+			r.AddError("BundleID", CodeBundleIDMismatch, key,
+				fmt.Errorf("%w: %v is not %v", ErrApplicationIdentifierMismatch, appID, id))
+		}
+	}
+
+	return r, nil
+}
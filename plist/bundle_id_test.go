@@ -0,0 +1,312 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/illyabusigin/apptools/entitlements"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/xcconfig"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestValidBundleID(t *testing.T) {
+	for _, id := range []string{"com.best.app", "com.best-app.Widget2", "io.1password.app"} {
+		assert.Nil(t, validBundleID(id), id)
+	}
+
+	for _, id := range []string{"com.best_app", "com.best app", "com.bést.app"} {
+		assert.True(t, errors.Is(validBundleID(id), ErrInvalidBundleID), id)
+	}
+
+	for _, id := range []string{"best", "com..best", ".com.best", "com.best."} {
+		assert.True(t, errors.Is(validBundleID(id), ErrNotReverseDNS), id)
+	}
+}
+
+func TestPropertyList_BundleIDValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		bundleID string
+		wantErr  error
+	}{
+		{
+			name:     "Reverse DNS bundle identifiers should be valid",
+			bundleID: "com.best.app",
+		},
+		{
+			name:     "Build setting references should not be validated",
+			bundleID: "$(PRODUCT_BUNDLE_IDENTIFIER)",
+		},
+		{
+			name:     "Bundle identifiers without a reverse DNS prefix should be reported",
+			bundleID: "bestapp",
+			wantErr:  ErrNotReverseDNS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := New(PlatformMac)
+			plist.Defaults()
+			plist.BundleName("Best App")
+			plist.BundleID(tt.bundleID)
+
+			issues := plist.Report().Errors()
+			if tt.wantErr == nil {
+				assert.Empty(t, issues)
+				return
+			}
+
+			assert.Len(t, issues, 1)
+			assert.Equal(t, "BundleID", issues[0].Path)
+			assert.Equal(t, CodeInvalidBundleID, issues[0].Code)
+			assert.True(t, errors.Is(issues[0], tt.wantErr))
+		})
+	}
+}
+
+func TestPropertyList_WatchBundleIDValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		bundleID  string
+		companion string
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "Watch apps nested under their companion app should be valid",
+			bundleID:  "com.best.app.watchkitapp",
+			companion: "com.best.app",
+			want:      []string{},
+		},
+		{
+			name:      "Watch apps not nested under their companion app should be reported",
+			bundleID:  "com.best.watchkitapp",
+			companion: "com.best.app",
+			want:      []string{"BundleID bundle-id-mismatch CFBundleIdentifier"},
+			wantErr:   ErrHostBundleIDPrefix,
+		},
+		{
+			name:      "Invalid companion app bundle identifiers should be reported",
+			bundleID:  "com.best.app.watchkitapp",
+			companion: "com.best_app",
+			want:      []string{"CompanionAppBundleID invalid-bundle-id WKCompanionAppBundleIdentifier"},
+			wantErr:   ErrInvalidBundleID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := New(PlatformWatch)
+			plist.Defaults()
+			plist.BundleName("Best App")
+			plist.DisplayName("Best App")
+			plist.BundleID(tt.bundleID)
+			plist.WatchApplication(true)
+			plist.CompanionAppBundleID(tt.companion)
+
+			issues := []string{}
+			for _, issue := range plist.Report().Errors() {
+				assert.True(t, errors.Is(issue, tt.wantErr))
+				issues = append(issues, issue.Path+" "+issue.Code+" "+issue.Key)
+			}
+
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
+
+func TestPropertyList_HostReport(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Extensions nested under the host app should not report any issues",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.BundleName("Widget")
+					plist.BundleID("com.best.app.widget")
+					plist.Extension(func(e *Extension) {
+						e.Widget()
+					})
+
+					return plist
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Unresolved bundle identifiers should not be checked",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.BundleName("Widget")
+					plist.BundleID("$(PRODUCT_BUNDLE_IDENTIFIER)")
+					plist.Extension(func(e *Extension) {
+						e.Widget()
+					})
+
+					return plist
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Extensions not nested under the host app should be reported",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.BundleName("Widget")
+					plist.BundleID("com.best.widget")
+					plist.Extension(func(e *Extension) {
+						e.Widget()
+					})
+
+					return plist
+				},
+			},
+			want:    []string{"BundleID bundle-id-mismatch"},
+			wantErr: ErrHostBundleIDPrefix,
+		},
+		{
+			name: "Watch apps of the host app should not report any issues",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformWatch)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.BundleID("com.best.app.watchkitapp")
+					plist.WatchApplication(true)
+					plist.CompanionAppBundleID("com.best.app")
+
+					return plist
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Watch apps of another app should be reported",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformWatch)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.BundleID("com.other.app.watchkitapp")
+					plist.WatchApplication(true)
+					plist.CompanionAppBundleID("com.other.app")
+
+					return plist
+				},
+			},
+			want: []string{
+				"BundleID bundle-id-mismatch",
+				"CompanionAppBundleID bundle-id-mismatch",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := New(PlatformIOS)
+			host.Defaults()
+			host.BundleName("Best App")
+			host.BundleID("com.best.app")
+
+			issues := []string{}
+			for _, issue := range tt.fields.plist().HostReport(host).Errors() {
+				if tt.wantErr != nil {
+					assert.True(t, errors.Is(issue, tt.wantErr))
+				}
+				issues = append(issues, issue.Path+" "+issue.Code)
+			}
+
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
+
+func TestPropertyList_CheckEntitlements(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		appID   string
+		wantErr error
+	}{
+		{
+			name:  "Application identifiers with a team prefix should match",
+			key:   "application-identifier",
+			appID: "ABCDE12345.com.best.app",
+		},
+		{
+			name:  "Application identifiers with a prefix reference should match",
+			key:   "application-identifier",
+			appID: "$(AppIdentifierPrefix)com.best.app",
+		},
+		{
+			name:  "Application identifiers referencing the bundle identifier should match",
+			key:   "application-identifier",
+			appID: "$(AppIdentifierPrefix)$(CFBundleIdentifier)",
+		},
+		{
+			name:    "Application identifiers of another app should be reported",
+			key:     "com.apple.application-identifier",
+			appID:   "ABCDE12345.com.best.other",
+			wantErr: ErrApplicationIdentifierMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := New(PlatformIOS)
+			plist.Defaults()
+			plist.BundleName("Best App")
+			plist.BundleID("com.best.app")
+
+			e := entitlements.New()
+			e.Format(format.JSON)
+			e.Set(tt.key, tt.appID)
+
+			r, err := plist.CheckEntitlements(e)
+			assert.Nil(t, err)
+
+			if tt.wantErr == nil {
+				assert.True(t, r.Empty())
+				return
+			}
+
+			issues := r.Errors()
+			assert.Len(t, issues, 1)
+			assert.Equal(t, "BundleID", issues[0].Path)
+			assert.Equal(t, CodeBundleIDMismatch, issues[0].Code)
+			assert.Equal(t, tt.key, issues[0].Key)
+			assert.True(t, errors.Is(issues[0], tt.wantErr))
+		})
+	}
+}
+
+func TestPropertyList_CompareEntitlementsBuildSettings(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("Best App")
+	plist.BuildSettings(xcconfig.Settings{
+		"PRODUCT_NAME":              "Best App",
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.best.$(PRODUCT_NAME:rfc1034identifier:lower)",
+	})
+
+	r, err := plist.CompareEntitlements([]byte(`<plist><dict><key>application-identifier</key><string>$(AppIdentifierPrefix)$(PRODUCT_BUNDLE_IDENTIFIER)</string></dict></plist>`))
+	assert.Nil(t, err)
+	assert.True(t, r.Empty())
+
+	r, err = plist.CompareEntitlements([]byte(`<plist><dict><key>application-identifier</key><string>$(AppIdentifierPrefix)$(OTHER_BUNDLE_IDENTIFIER)</string></dict></plist>`))
+	assert.Nil(t, err)
+	assert.Len(t, r.Errors(), 0)
+	assert.Len(t, r.Warnings(), 1)
+	assert.Equal(t, CodeUnresolvedVariable, r.Warnings()[0].Code)
+}
@@ -33,11 +33,12 @@ type DeviceCapabilities struct {
 }
 
 // Apply will apply the device capabilities to the specified property list
-func (c *DeviceCapabilities) Apply(p *PropertyList) {
-	data := c.build()
+// dictionary.
+func (c *DeviceCapabilities) Apply(data map[string]interface{}) {
+	capabilities := c.build()
 
-	if len(data) > 0 {
-		p.data["UIRequiredDeviceCapabilities"] = data
+	if len(capabilities) > 0 {
+		data[keyUIRequiredDeviceCapabilities] = capabilities
 	}
 }
 
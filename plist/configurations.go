@@ -0,0 +1,232 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/illyabusigin/apptools/buildconfig"
+	"github.com/illyabusigin/apptools/filesystem"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// ErrUnknownConfiguration is the error returned for build configurations that
+// have not been declared.
+var ErrUnknownConfiguration = errors.New("Unknown configuration")
+
+// configuration is a named overlay applied on top of the base property list.
+type configuration struct {
+	name    string
+	overlay func(p *PropertyList)
+}
+
+// Difference describes a key whose value differs between build
+// configurations.
+type Difference = buildconfig.Difference
+
+// Configuration declares a build configuration, i.e. `Debug` or `Release`,
+// as an overlay on top of the base property list. The overlay is applied to
+// an empty property list for the same platform and every key it sets replaces
+// the corresponding top-level key of the base property list, including keys
+// set back to their zero value, i.e. `StatusBarHidden(false)`, while the keys
+// it removes with `Remove` are removed from the base property list. Overlays
+// inherit the base build settings and may specify their own with
+// `BuildSettings`. Declaring a configuration with the same name replaces the
+// previous overlay.
+func (p *PropertyList) Configuration(name string, f func(p *PropertyList)) *PropertyList {
+	for idx, c := range p.configurations {
+		if c.name == name {
+			p.configurations[idx].overlay = f
+			return p
+		}
+	}
+
+	p.configurations = append(p.configurations, configuration{name: name, overlay: f})
+
+	return p
+}
+
+// Configurations returns the names of the declared build configurations in
+// the order they were declared.
+func (p *PropertyList) Configurations() []string {
+	names := make([]string, len(p.configurations))
+	for idx, c := range p.configurations {
+		names[idx] = c.name
+	}
+
+	return names
+}
+
+func (p *PropertyList) overlay(name string) (*PropertyList, error) {
+	for _, c := range p.configurations {
+		if c.name == name {
+			o := New(p.platform)
+			o.touched = map[string]bool{}
+			o.buildSettings = p.buildSettings
+			c.overlay(o)
+			return o, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUnknownConfiguration, name)
+}
+
+// touch records that the setter of a key has been called on an overlay, so
+// the overlay can set a key back to its zero value, i.e.
+// `StatusBarHidden(false)`. Only overlays record the keys.
+func (p *PropertyList) touch(key string) {
+	if p.touched != nil {
+		p.touched[key] = true
+	}
+}
+
+// resolveConfiguration computes the property list dictionary of a build
+// configuration, along with the unresolved build setting references of each
+// key. The overlay replaces the keys whose setters it called, and the keys
+// whose values differ from an empty property list, and removes the keys it
+// removes.
+func (p *PropertyList) resolveConfiguration(name string) (map[string]interface{}, map[string][]string, error) {
+	o, err := p.overlay(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := copyValue(p.buildData()).(map[string]interface{})
+
+	overlay := o.buildData()
+	for key := range o.touched {
+		if _, found := overlay[key]; !found {
+			delete(data, key)
+		}
+	}
+
+	empty := New(p.platform).buildData()
+	for key, value := range overlay {
+		if current, found := empty[key]; o.touched[key] || !found || !reflect.DeepEqual(current, value) {
+			data[key] = copyValue(value)
+		}
+	}
+
+	for key := range o.removed {
+		delete(data, key)
+	}
+
+	if o.buildSettings == nil {
+		return data, nil, nil
+	}
+
+	data, unresolved := expandBuildSettings(o.buildSettings, data)
+
+	return data, unresolved, nil
+}
+
+// ConfigurationReport will validate the base property list and the overlay
+// of the specified build configuration. Overlays are not required to declare
+// the properties inherited from the base property list. Build setting
+// references are checked against the settings of the configuration.
+func (p *PropertyList) ConfigurationReport(name string) (*validation.Report, error) {
+	o, err := p.overlay(name)
+	if err != nil {
+		return nil, err
+	}
+
+	base := p.Report()
+
+	// Missing properties are inherited from the base property list, which
+	// reports them itself when they are missing there too.
+	inherited := map[string]bool{}
+	for key := range p.build() {
+		inherited[key] = true
+	}
+
+	r := &validation.Report{}
+	for _, issue := range base.Issues {
+		if issue.Code == CodeMissingProperty {
+			inherited[issue.Key] = true
+		}
+
+		if issue.Code != CodeUnresolvedVariable {
+			r.Issues = append(r.Issues, issue)
+		}
+	}
+
+	overlay := &validation.Report{}
+	for _, issue := range o.Report().Issues {
+		if issue.Code == CodeUnresolvedVariable || issue.Code == CodeMissingProperty && inherited[issue.Key] {
+			continue
+		}
+
+		overlay.Issues = append(overlay.Issues, issue)
+	}
+
+	_, unresolved, err := p.resolveConfiguration(name)
+	if err != nil {
+		return nil, err
+	}
+
+	unresolvedReport(overlay, "", unresolved)
+
+	r.Merge(fmt.Sprintf("Configurations[%v]", name), overlay)
+
+	return r, nil
+}
+
+// BuildConfiguration will build the Info.plist of the specified build
+// configuration.
+func (p *PropertyList) BuildConfiguration(name string) (string, error) {
+	if !p.skipValidation {
+		r, err := p.ConfigurationReport(name)
+		if err != nil {
+			return "", err
+		}
+
+		if err := r.Err(); err != nil {
+			return "", err
+		}
+	}
+
+	data, _, err := p.resolveConfiguration(name)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := format.Encode(data, p.format)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// SaveConfigurations builds and writes one property list per build
+// configuration next to the specified path, inserting the configuration name
+// before the extension, i.e. `Info.plist` is written as `Info-Debug.plist`
+// and `Info-Release.plist`.
+func (p *PropertyList) SaveConfigurations(path string) error {
+	return p.SaveConfigurationsFS(filesystem.OSFileSystem{}, path)
+}
+
+// SaveConfigurationsFS builds and writes one property list per build
+// configuration within the provided file system, see `SaveConfigurations`.
+func (p *PropertyList) SaveConfigurationsFS(fsys filesystem.FileSystem, path string) error {
+	return buildconfig.Save(fsys, path, p.Configurations(), p.BuildConfiguration)
+}
+
+// ConfigurationDifferences returns the keys whose values differ between the
+// declared build configurations, sorted by key.
+func (p *PropertyList) ConfigurationDifferences() ([]Difference, error) {
+	names := p.Configurations()
+
+	resolved := map[string]map[string]interface{}{}
+	for _, name := range names {
+		data, _, err := p.resolveConfiguration(name)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved[name] = data
+	}
+
+	return buildconfig.Differences(names, resolved), nil
+}
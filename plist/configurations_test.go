@@ -0,0 +1,372 @@
+package plist
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_Configuration(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.Configuration("Debug", func(p *PropertyList) {
+		p.AppTransportSecurity(func(s *AppTransportSecurity) {
+			s.AllowArbitraryLoads(true)
+		})
+	})
+	plist.Configuration("Release", func(p *PropertyList) {})
+	plist.Configuration("Debug", func(p *PropertyList) {
+		p.Set("APIBaseURL", "http://localhost")
+	})
+	plist.Configuration("Staging", func(p *PropertyList) {})
+
+	assert.Equal(t, []string{"Debug", "Release", "Staging"}, plist.Configurations())
+
+	out, err := plist.BuildConfiguration("Debug")
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>APIBaseURL</key><string>http://localhost</string>")
+	assert.NotContains(t, out, keyNSAppTransportSecurity, "Redeclared configurations should replace the previous overlay")
+}
+
+func TestPropertyList_BuildConfiguration(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		configuration string
+		contains      []string
+		notContains   []string
+		wantErr       error
+	}{
+		{
+			name: "Overlay keys should replace the base keys",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Set("APIBaseURL", "https://api.best.app")
+					plist.Configuration("Debug", func(p *PropertyList) {
+						p.AppTransportSecurity(func(s *AppTransportSecurity) {
+							s.AllowArbitraryLoads(true)
+						})
+						p.Set("APIBaseURL", "http://localhost:8080")
+					})
+
+					return plist
+				},
+			},
+			configuration: "Debug",
+			contains: []string{
+				"<key>APIBaseURL</key><string>http://localhost:8080</string>",
+				"<key>NSAllowsArbitraryLoads</key><true/>",
+				"<key>CFBundleName</key><string>BestApp</string>",
+			},
+		},
+		{
+			name: "Empty overlays should inherit the base keys",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Set("APIBaseURL", "https://api.best.app")
+					plist.Configuration("Release", func(p *PropertyList) {})
+
+					return plist
+				},
+			},
+			configuration: "Release",
+			contains:      []string{"<key>APIBaseURL</key><string>https://api.best.app</string>"},
+			notContains:   []string{keyNSAppTransportSecurity},
+		},
+		{
+			name: "Overlays should set keys back to their zero value",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.SkipValidation()
+					plist.StatusBarHidden(true)
+					plist.Configuration("Release", func(p *PropertyList) {
+						p.StatusBarHidden(false)
+					})
+
+					return plist
+				},
+			},
+			configuration: "Release",
+			contains:      []string{"<key>UIStatusBarHidden</key><false/>"},
+		},
+		{
+			name: "Overlays should clear keys set to an empty value",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Copyright("Copyright © Best")
+					plist.MainStoryboard("Main")
+					plist.Configuration("Release", func(p *PropertyList) {
+						p.Copyright("")
+						p.MainStoryboard("")
+					})
+
+					return plist
+				},
+			},
+			configuration: "Release",
+			notContains:   []string{keyNSHumanReadableCopyright, keyUIMainStoryboardFile},
+		},
+		{
+			name: "Overlays should replace every key set by their sections",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.SkipValidation()
+					plist.LaunchScreenStoryboard("Launch")
+					plist.Privacy(func(p *Privacy) {
+						p.Camera("We scan receipts")
+					})
+					plist.URLType(func(u *URLType) {
+						u.Name("com.best.app").Schemes("best")
+					})
+					plist.Configuration("Debug", func(p *PropertyList) {
+						p.LaunchScreenStoryboard("LaunchDebug")
+						p.Privacy(func(p *Privacy) {
+							p.Camera("We scan receipts while debugging")
+						})
+						p.URLType(func(u *URLType) {
+							u.Name("com.best.app.debug").Schemes("best-debug")
+						})
+					})
+
+					return plist
+				},
+			},
+			configuration: "Debug",
+			contains: []string{
+				"<key>UILaunchStoryboardName</key><string>LaunchDebug</string>",
+				"<key>NSCameraUsageDescription</key><string>We scan receipts while debugging</string>",
+				"<string>best-debug</string>",
+			},
+			notContains: []string{"<string>best</string>"},
+		},
+		{
+			name: "Removed keys should be removed from the base keys",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Copyright("Copyright © Best")
+					plist.Set("APIBaseURL", "https://api.best.app")
+					plist.Configuration("Release", func(p *PropertyList) {
+						p.Remove(keyNSHumanReadableCopyright, "APIBaseURL")
+					})
+
+					return plist
+				},
+			},
+			configuration: "Release",
+			notContains:   []string{keyNSHumanReadableCopyright, "APIBaseURL"},
+		},
+		{
+			name: "Undeclared configurations should fail",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Configuration("Debug", func(p *PropertyList) {})
+
+					return plist
+				},
+			},
+			configuration: "Profile",
+			wantErr:       ErrUnknownConfiguration,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.fields.plist().BuildConfiguration(tt.configuration)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.Nil(t, err)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, out, s)
+			}
+
+			for _, s := range tt.notContains {
+				assert.NotContains(t, out, s)
+			}
+		})
+	}
+}
+
+func TestPropertyList_BuildConfigurationUnchanged(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.Set("APIBaseURL", "https://api.best.app")
+	plist.Configuration("Release", func(p *PropertyList) {})
+
+	release, err := plist.BuildConfiguration("Release")
+	assert.Nil(t, err)
+
+	base, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, base, release, "Empty overlays should build the base property list")
+}
+
+func TestPropertyList_ConfigurationReport(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.Configuration("Release", func(p *PropertyList) {
+		p.URLType(func(u *URLType) {
+			u.Schemes("1best")
+		})
+	})
+
+	report, err := plist.ConfigurationReport("Release")
+	assert.Nil(t, err)
+
+	issues := report.Errors()
+	assert.Len(t, issues, 1)
+	assert.True(t, strings.HasPrefix(issues[0].Path, "Configurations[Release]."))
+	assert.Equal(t, CodeInvalidURLScheme, issues[0].Code)
+
+	_, err = plist.BuildConfiguration("Release")
+	assert.True(t, errors.Is(err, ErrInvalidURLScheme))
+
+	_, err = plist.ConfigurationReport("Profile")
+	assert.True(t, errors.Is(err, ErrUnknownConfiguration))
+}
+
+func TestPropertyList_ConfigurationDifferences(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []Difference
+	}{
+		{
+			name: "Keys set by a single configuration should differ",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("BestApp")
+					plist.Set("APIBaseURL", "https://api.best.app")
+					plist.Configuration("Debug", func(p *PropertyList) {
+						p.Set("APIBaseURL", "http://localhost:8080")
+						p.Set("Debugging", true)
+					})
+					plist.Configuration("Release", func(p *PropertyList) {})
+
+					return plist
+				},
+			},
+			want: []Difference{
+				{Key: "APIBaseURL", Values: map[string]interface{}{"Debug": "http://localhost:8080", "Release": "https://api.best.app"}},
+				{Key: "Debugging", Values: map[string]interface{}{"Debug": true}},
+			},
+		},
+		{
+			name: "Keys set back to their zero value should differ",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.Defaults()
+					plist.SkipValidation()
+					plist.StatusBarHidden(true)
+					plist.Configuration("Debug", func(p *PropertyList) {})
+					plist.Configuration("Release", func(p *PropertyList) {
+						p.StatusBarHidden(false)
+					})
+
+					return plist
+				},
+			},
+			want: []Difference{
+				{Key: keyUIStatusBarHidden, Values: map[string]interface{}{"Debug": true, "Release": false}},
+			},
+		},
+		{
+			name: "Property lists without configurations should not have any differences",
+			fields: fields{
+				plist: func() *PropertyList {
+					return New(PlatformIOS)
+				},
+			},
+			want: []Difference{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := tt.fields.plist().ConfigurationDifferences()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, diffs)
+		})
+	}
+}
+
+func TestPropertyList_Remove(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.Copyright("Copyright © Best")
+	plist.Set("APIBaseURL", "https://api.best.app")
+	plist.Remove(keyNSHumanReadableCopyright, "APIBaseURL")
+
+	out, err := plist.Build()
+	assert.Nil(t, err)
+	assert.NotContains(t, out, keyNSHumanReadableCopyright)
+	assert.NotContains(t, out, "APIBaseURL")
+
+	plist.Set("APIBaseURL", "https://api.best.app")
+
+	out, err = plist.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "APIBaseURL", "Setting a removed key should restore it")
+}
+
+func TestPropertyList_SaveConfigurationsFS(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.Set("APIBaseURL", "https://api.best.app")
+	plist.Configuration("Debug", func(p *PropertyList) {
+		p.Set("APIBaseURL", "http://localhost:8080")
+	})
+	plist.Configuration("Release", func(p *PropertyList) {})
+
+	fsys := filesystem.NewMemoryFileSystem()
+	assert.Nil(t, fsys.MkdirAll("App", os.ModePerm))
+	assert.Nil(t, plist.SaveConfigurationsFS(fsys, "App/Info.plist"))
+	assert.Equal(t, []string{"App/Info-Debug.plist", "App/Info-Release.plist"}, fsys.Files())
+
+	data, err := fsys.ReadFile("App/Info-Debug.plist")
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "http://localhost:8080")
+
+	plist.Configuration("Release", func(p *PropertyList) {
+		p.URLType(func(u *URLType) {})
+	})
+	assert.True(t, errors.Is(plist.SaveConfigurationsFS(fsys, "App/Info.plist"), ErrMissingRequiredProperty))
+}
@@ -2,21 +2,82 @@ package plist
 
 const (
 	// PLIST
-	keyUISupportedInterfaceOrientations     = "UISupportedInterfaceOrientations"
-	keyUISupportedInterfaceOrientationsIPad = "UISupportedInterfaceOrientations~ipad"
-	keyUIStatusBarHidden                    = "UIStatusBarHidden"
-	keyUIStatusBarStyle                     = "UIStatusBarStyle"
-	keyCFBundleDisplayName                  = "CFBundleDisplayName"
-	keyCFBundleName                         = "CFBundleName"
-	keyCFBundleIdentifier                   = "CFBundleIdentifier"
-	keyCFBundleDevelopmentRegion            = "CFBundleDevelopmentRegion"
-	keyCFBundleExecutable                   = "CFBundleExecutable"
-	keyCFBundleInfoDictionaryVersion        = "CFBundleInfoDictionaryVersion"
-	keyCFBundlePackageType                  = "CFBundlePackageType"
-	keyCFBundleShortVersionString           = "CFBundleShortVersionString"
-	keyCFBundleVersion                      = "CFBundleVersion"
-	keyLSRequiresIPhoneOS                   = "LSRequiresIPhoneOS"
-	keyNSAppTransportSecurity               = "NSAppTransportSecurity"
+	keyUISupportedInterfaceOrientations         = "UISupportedInterfaceOrientations"
+	keyUISupportedInterfaceOrientationsIPad     = "UISupportedInterfaceOrientations~ipad"
+	keyUIStatusBarHidden                        = "UIStatusBarHidden"
+	keyUIStatusBarStyle                         = "UIStatusBarStyle"
+	keyCFBundleDisplayName                      = "CFBundleDisplayName"
+	keyCFBundleName                             = "CFBundleName"
+	keyCFBundleIdentifier                       = "CFBundleIdentifier"
+	keyCFBundleDevelopmentRegion                = "CFBundleDevelopmentRegion"
+	keyCFBundleExecutable                       = "CFBundleExecutable"
+	keyCFBundleInfoDictionaryVersion            = "CFBundleInfoDictionaryVersion"
+	keyCFBundlePackageType                      = "CFBundlePackageType"
+	keyCFBundleShortVersionString               = "CFBundleShortVersionString"
+	keyCFBundleVersion                          = "CFBundleVersion"
+	keyLSRequiresIPhoneOS                       = "LSRequiresIPhoneOS"
+	keyNSAppTransportSecurity                   = "NSAppTransportSecurity"
+	keyUIApplicationSceneManifest               = "UIApplicationSceneManifest"
+	keyUIRequiredDeviceCapabilities             = "UIRequiredDeviceCapabilities"
+	keyUILaunchStoryboardName                   = "UILaunchStoryboardName"
+	keyUIMainStoryboardFile                     = "UIMainStoryboardFile"
+	keyUIViewControllerBasedStatusBarAppearance = "UIViewControllerBasedStatusBarAppearance"
+
+	// macOS
+	keyLSMinimumSystemVersion    = "LSMinimumSystemVersion"
+	keyNSPrincipalClass          = "NSPrincipalClass"
+	keyLSApplicationCategoryType = "LSApplicationCategoryType"
+	keyNSHumanReadableCopyright  = "NSHumanReadableCopyright"
+	keyNSMainNibFile             = "NSMainNibFile"
+
+	// watchOS
+	keyWKApplication                     = "WKApplication"
+	keyWKCompanionAppBundleIdentifier    = "WKCompanionAppBundleIdentifier"
+	keyWKRunsIndependentlyOfCompanionApp = "WKRunsIndependentlyOfCompanionApp"
+
+	// tvOS
+	keyUIAppSupportsHDR = "UIAppSupportsHDR"
+
+	// visionOS
+	keyUIApplicationPreferredDefaultSceneSessionRole = "UIApplicationPreferredDefaultSceneSessionRole"
+	keyUISceneInitialImmersionStyle                  = "UISceneInitialImmersionStyle"
+
+	// Background execution
+	keyUIBackgroundModes                   = "UIBackgroundModes"
+	keyBGTaskSchedulerPermittedIdentifiers = "BGTaskSchedulerPermittedIdentifiers"
+	keyLSApplicationQueriesSchemes         = "LSApplicationQueriesSchemes"
+
+	// URL and document types
+	keyCFBundleURLTypes           = "CFBundleURLTypes"
+	keyCFBundleURLName            = "CFBundleURLName"
+	keyCFBundleURLSchemes         = "CFBundleURLSchemes"
+	keyCFBundleURLIconFile        = "CFBundleURLIconFile"
+	keyCFBundleTypeRole           = "CFBundleTypeRole"
+	keyCFBundleDocumentTypes      = "CFBundleDocumentTypes"
+	keyCFBundleTypeName           = "CFBundleTypeName"
+	keyCFBundleTypeIconFiles      = "CFBundleTypeIconFiles"
+	keyLSItemContentTypes         = "LSItemContentTypes"
+	keyLSHandlerRank              = "LSHandlerRank"
+	keyUTExportedTypeDeclarations = "UTExportedTypeDeclarations"
+	keyUTImportedTypeDeclarations = "UTImportedTypeDeclarations"
+	keyUTTypeIdentifier           = "UTTypeIdentifier"
+	keyUTTypeDescription          = "UTTypeDescription"
+	keyUTTypeConformsTo           = "UTTypeConformsTo"
+	keyUTTypeIconFile             = "UTTypeIconFile"
+	keyUTTypeReferenceURL         = "UTTypeReferenceURL"
+	keyUTTypeTagSpecification     = "UTTypeTagSpecification"
+
+	// App extensions
+	keyNSExtension                                    = "NSExtension"
+	keyNSExtensionPointIdentifier                     = "NSExtensionPointIdentifier"
+	keyNSExtensionPrincipalClass                      = "NSExtensionPrincipalClass"
+	keyNSExtensionMainStoryboard                      = "NSExtensionMainStoryboard"
+	keyNSExtensionAttributes                          = "NSExtensionAttributes"
+	keyNSExtensionActivationRule                      = "NSExtensionActivationRule"
+	keyIntentsSupported                               = "IntentsSupported"
+	keyIntentsRestrictedWhileLocked                   = "IntentsRestrictedWhileLocked"
+	keyUNNotificationExtensionCategory                = "UNNotificationExtensionCategory"
+	keyUNNotificationExtensionInitialContentSizeRatio = "UNNotificationExtensionInitialContentSizeRatio"
 
 	// ATS
 	atsNSAllowsArbitraryLoads             = "NSAllowsArbitraryLoads"
@@ -35,6 +96,15 @@ const (
 
 	// PlatformMac is for validating the plist against the Mac/OSX plaftform
 	PlatformMac Platform = "mac"
+
+	// PlatformWatch is for validating the plist against the watchOS platform
+	PlatformWatch Platform = "watchos"
+
+	// PlatformTV is for validating the plist against the tvOS platform
+	PlatformTV Platform = "tvos"
+
+	// PlatformVision is for validating the plist against the visionOS platform
+	PlatformVision Platform = "visionos"
 )
 
 var privacyKeys = map[string]string{
@@ -75,4 +145,5 @@ var privacyKeys = map[string]string{
 	"systemAdminstration":     "NSSystemAdministrationUsageDescription",
 	"systemExtension":         "NSSystemExtensionUsageDescription",
 	"tvProvider":              "NSVideoSubscriberAccountUsageDescription",
+	"userTracking":            "NSUserTrackingUsageDescription",
 }
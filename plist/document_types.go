@@ -0,0 +1,411 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for document types and type declarations.
+const (
+	CodeInvalidUTI    = "invalid-uti"
+	CodeDuplicateUTI  = "duplicate-uti"
+	CodeUndeclaredUTI = "undeclared-uti"
+)
+
+var (
+	// ErrInvalidUTI is the error returned for malformed Uniform Type
+	// Identifiers.
+	ErrInvalidUTI = errors.New("Uniform Type Identifiers must be reverse DNS strings containing letters, digits, '-' and '.'")
+
+	// ErrDuplicateUTI is the error returned for type identifiers declared more
+	// than once.
+	ErrDuplicateUTI = errors.New("Uniform Type Identifier is declared more than once")
+
+	// ErrSelfConformance is the error returned for types that conform to
+	// themselves.
+	ErrSelfConformance = errors.New("Uniform Type Identifier cannot conform to itself")
+
+	// ErrUndeclaredUTI is the warning returned for document content types that
+	// are neither system types nor declared by the property list.
+	ErrUndeclaredUTI = errors.New("Content type is not a system type and is not declared by an exported or imported type")
+
+	utiRegex = regexp.MustCompile(`^[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)+$`)
+
+	// systemUTIPrefixes are the prefixes of the types declared by the system.
+	systemUTIPrefixes = []string{"public.", "com.apple.", "dyn."}
+)
+
+// HandlerRank describes how the app ranks itself among the apps that declare
+// they can open a document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/lshandlerrank for more information.
+type HandlerRank string
+
+const (
+	// RankOwner specifies the app is the creator of the type.
+	RankOwner HandlerRank = "Owner"
+
+	// RankDefault specifies the app is the primary viewer or editor.
+	RankDefault HandlerRank = "Default"
+
+	// RankAlternate specifies the app is a secondary viewer or editor.
+	RankAlternate HandlerRank = "Alternate"
+
+	// RankNone specifies the app must never be used to open the type.
+	RankNone HandlerRank = "None"
+)
+
+// DocumentType describes a document type the app can open.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes for more information.
+type DocumentType struct {
+	name         string
+	role         BundleTypeRole
+	rank         HandlerRank
+	contentTypes []string
+	iconFiles    []string
+}
+
+// Name specifies the abstract name for the document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/cfbundletypename for more information.
+func (d *DocumentType) Name(v string) *DocumentType {
+	d.name = v
+	return d
+}
+
+// Role specifies the app's role with respect to the document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/cfbundletyperole for more information.
+func (d *DocumentType) Role(v BundleTypeRole) *DocumentType {
+	d.role = v
+	return d
+}
+
+// HandlerRank specifies the app's ranking among the apps that can open the
+// document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/lshandlerrank for more information.
+func (d *DocumentType) HandlerRank(v HandlerRank) *DocumentType {
+	d.rank = v
+	return d
+}
+
+// ContentTypes specifies the Uniform Type Identifiers of the document type,
+// i.e. `public.plain-text`. Custom types must be declared with
+// `ExportedType` or `ImportedType`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/lsitemcontenttypes for more information.
+func (d *DocumentType) ContentTypes(types ...string) *DocumentType {
+	d.contentTypes = append(d.contentTypes, types...)
+	return d
+}
+
+// IconFiles specifies the names of the icon files used for the document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/cfbundletypeiconfiles for more information.
+func (d *DocumentType) IconFiles(files ...string) *DocumentType {
+	d.iconFiles = append(d.iconFiles, files...)
+	return d
+}
+
+// Validate will validate the document type and return any errors found.
+func (d *DocumentType) Validate() error {
+	return d.Report().Err()
+}
+
+// Report will validate the document type and return a report containing every
+// issue found. Undeclared content types are reported by the property list.
+func (d *DocumentType) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if d.name == "" {
+		r.AddError("Name", CodeMissingProperty, keyCFBundleTypeName, ErrMissingRequiredProperty)
+	}
+
+	if len(d.contentTypes) == 0 {
+		r.AddError("ContentTypes", CodeMissingProperty, keyLSItemContentTypes, ErrMissingRequiredProperty)
+	}
+
+	for idx, uti := range d.contentTypes {
+		if !utiRegex.MatchString(uti) {
+			r.AddError(validation.Index("ContentTypes", idx), CodeInvalidUTI, keyLSItemContentTypes,
+				fmt.Errorf("%w: %q", ErrInvalidUTI, uti))
+		}
+	}
+
+	return r
+}
+
+func (d *DocumentType) build() map[string]interface{} {
+	data := map[string]interface{}{
+		keyCFBundleTypeName:   d.name,
+		keyLSItemContentTypes: d.contentTypes,
+	}
+
+	if d.role != "" {
+		data[keyCFBundleTypeRole] = string(d.role)
+	}
+
+	if d.rank != "" {
+		data[keyLSHandlerRank] = string(d.rank)
+	}
+
+	if len(d.iconFiles) > 0 {
+		data[keyCFBundleTypeIconFiles] = d.iconFiles
+	}
+
+	return data
+}
+
+// TypeDeclaration describes a Uniform Type Identifier exported or imported by
+// the app.
+// See https://developer.apple.com/documentation/uniformtypeidentifiers/defining_file_and_data_types_for_your_app for more information.
+type TypeDeclaration struct {
+	identifier   string
+	description  string
+	conformsTo   []string
+	extensions   []string
+	mimeTypes    []string
+	iconFile     string
+	referenceURL string
+}
+
+// Identifier specifies the Uniform Type Identifier, i.e. `com.best.app.doc`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypeidentifier for more information.
+func (t *TypeDeclaration) Identifier(v string) *TypeDeclaration {
+	t.identifier = v
+	return t
+}
+
+// Description specifies a user-visible description of the type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypedescription for more information.
+func (t *TypeDeclaration) Description(v string) *TypeDeclaration {
+	t.description = v
+	return t
+}
+
+// ConformsTo specifies the Uniform Type Identifiers the type conforms to, i.e.
+// `public.data` and `public.content`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypeconformsto for more information.
+func (t *TypeDeclaration) ConformsTo(types ...string) *TypeDeclaration {
+	t.conformsTo = append(t.conformsTo, types...)
+	return t
+}
+
+// Extensions specifies the filename extensions of the type, without the
+// leading dot.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypetagspecification for more information.
+func (t *TypeDeclaration) Extensions(extensions ...string) *TypeDeclaration {
+	t.extensions = append(t.extensions, extensions...)
+	return t
+}
+
+// MIMETypes specifies the MIME types of the type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypetagspecification for more information.
+func (t *TypeDeclaration) MIMETypes(types ...string) *TypeDeclaration {
+	t.mimeTypes = append(t.mimeTypes, types...)
+	return t
+}
+
+// IconFile specifies the name of the icon file used for the type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypeiconfile for more information.
+func (t *TypeDeclaration) IconFile(v string) *TypeDeclaration {
+	t.iconFile = v
+	return t
+}
+
+// ReferenceURL specifies the URL of a reference document describing the type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations/uttypereferenceurl for more information.
+func (t *TypeDeclaration) ReferenceURL(v string) *TypeDeclaration {
+	t.referenceURL = v
+	return t
+}
+
+// Validate will validate the type declaration and return any errors found.
+func (t *TypeDeclaration) Validate() error {
+	return t.Report().Err()
+}
+
+// Report will validate the type declaration and return a report containing
+// every issue found.
+func (t *TypeDeclaration) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if t.identifier == "" {
+		r.AddError("Identifier", CodeMissingProperty, keyUTTypeIdentifier, ErrMissingRequiredProperty)
+	} else if !utiRegex.MatchString(t.identifier) {
+		r.AddError("Identifier", CodeInvalidUTI, keyUTTypeIdentifier, fmt.Errorf("%w: %q", ErrInvalidUTI, t.identifier))
+	}
+
+	if len(t.conformsTo) == 0 {
+		r.AddError("ConformsTo", CodeMissingProperty, keyUTTypeConformsTo, ErrMissingRequiredProperty)
+	}
+
+	for idx, uti := range t.conformsTo {
+		path := validation.Index("ConformsTo", idx)
+
+		switch {
+		case !utiRegex.MatchString(uti):
+			r.AddError(path, CodeInvalidUTI, keyUTTypeConformsTo, fmt.Errorf("%w: %q", ErrInvalidUTI, uti))
+		case uti == t.identifier:
+			r.AddError(path, CodeInvalidUTI, keyUTTypeConformsTo, fmt.Errorf("%w: %q", ErrSelfConformance, uti))
+		}
+	}
+
+	return r
+}
+
+func (t *TypeDeclaration) build() map[string]interface{} {
+	data := map[string]interface{}{
+		keyUTTypeIdentifier: t.identifier,
+		keyUTTypeConformsTo: t.conformsTo,
+	}
+
+	if t.description != "" {
+		data[keyUTTypeDescription] = t.description
+	}
+
+	if t.iconFile != "" {
+		data[keyUTTypeIconFile] = t.iconFile
+	}
+
+	if t.referenceURL != "" {
+		data[keyUTTypeReferenceURL] = t.referenceURL
+	}
+
+	tags := map[string]interface{}{}
+
+	if len(t.extensions) > 0 {
+		tags["public.filename-extension"] = t.extensions
+	}
+
+	if len(t.mimeTypes) > 0 {
+		tags["public.mime-type"] = t.mimeTypes
+	}
+
+	if len(tags) > 0 {
+		data[keyUTTypeTagSpecification] = tags
+	}
+
+	return data
+}
+
+// isSystemUTI returns true for identifiers declared by the system.
+func isSystemUTI(uti string) bool {
+	for _, prefix := range systemUTIPrefixes {
+		if strings.HasPrefix(uti, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// documentTypesReport validates the document types and type declarations of
+// a property list. Type identifiers must be unique and document content types
+// should either be system types or declared types.
+func documentTypesReport(documents []*DocumentType, exported, imported []*TypeDeclaration) *validation.Report {
+	r := &validation.Report{}
+	declared := map[string]bool{}
+
+	declarations := []struct {
+		path  string
+		types []*TypeDeclaration
+	}{
+		{"ExportedTypes", exported},
+		{"ImportedTypes", imported},
+	}
+
+	for _, group := range declarations {
+		for idx, t := range group.types {
+			path := validation.Index(group.path, idx)
+			r.Merge(path, t.Report())
+
+			if t.identifier == "" {
+				continue
+			}
+
+			if declared[t.identifier] {
+				r.AddError(validation.Join(path, "Identifier"), CodeDuplicateUTI, keyUTTypeIdentifier,
+					fmt.Errorf("%w: %q", ErrDuplicateUTI, t.identifier))
+			}
+
+			declared[t.identifier] = true
+		}
+	}
+
+	for idx, d := range documents {
+		path := validation.Index("DocumentTypes", idx)
+		r.Merge(path, d.Report())
+
+		for cIdx, uti := range d.contentTypes {
+			if declared[uti] || isSystemUTI(uti) || !utiRegex.MatchString(uti) {
+				continue
+			}
+
+			r.AddWarning(validation.Join(path, validation.Index("ContentTypes", cIdx)), CodeUndeclaredUTI,
+				keyLSItemContentTypes, fmt.Errorf("%w: %q", ErrUndeclaredUTI, uti))
+		}
+	}
+
+	return r
+}
+
+func buildTypeDeclarations(types []*TypeDeclaration) []map[string]interface{} {
+	data := make([]map[string]interface{}, len(types))
+	for idx, t := range types {
+		data[idx] = t.build()
+	}
+
+	return data
+}
+
+// DocumentType declares a document type the app can open. Call it once per
+// document type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes for more information.
+func (p *PropertyList) DocumentType(f func(d *DocumentType)) *PropertyList {
+	p.touch(keyCFBundleDocumentTypes)
+	d := &DocumentType{}
+	f(d)
+	p.documentTypes = append(p.documentTypes, d)
+	return p
+}
+
+// ExportedType declares a Uniform Type Identifier owned by the app. Call it
+// once per type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utexportedtypedeclarations for more information.
+func (p *PropertyList) ExportedType(f func(t *TypeDeclaration)) *PropertyList {
+	p.touch(keyUTExportedTypeDeclarations)
+	t := &TypeDeclaration{}
+	f(t)
+	p.exportedTypes = append(p.exportedTypes, t)
+	return p
+}
+
+// ImportedType declares a Uniform Type Identifier owned by another app that
+// this app uses. Call it once per type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/utimportedtypedeclarations for more information.
+func (p *PropertyList) ImportedType(f func(t *TypeDeclaration)) *PropertyList {
+	p.touch(keyUTImportedTypeDeclarations)
+	t := &TypeDeclaration{}
+	f(t)
+	p.importedTypes = append(p.importedTypes, t)
+	return p
+}
+
+func (p *PropertyList) applyDocumentTypes(data map[string]interface{}) {
+	if len(p.documentTypes) > 0 {
+		documentTypes := make([]map[string]interface{}, len(p.documentTypes))
+		for idx, d := range p.documentTypes {
+			documentTypes[idx] = d.build()
+		}
+
+		data[keyCFBundleDocumentTypes] = documentTypes
+	}
+
+	if len(p.exportedTypes) > 0 {
+		data[keyUTExportedTypeDeclarations] = buildTypeDeclarations(p.exportedTypes)
+	}
+
+	if len(p.importedTypes) > 0 {
+		data[keyUTImportedTypeDeclarations] = buildTypeDeclarations(p.importedTypes)
+	}
+}
@@ -0,0 +1,63 @@
+package plist
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_DocumentTypes(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.ExportedType(func(t *TypeDeclaration) {
+		t.Identifier("com.best.app.doc").
+			Description("Best App Document").
+			ConformsTo("public.data", "public.content").
+			Extensions("bestdoc").
+			MIMETypes("application/x-bestdoc")
+	})
+	plist.ImportedType(func(t *TypeDeclaration) {
+		t.Identifier("com.best.app.doc").ConformsTo("com.best.app.doc", "not a uti")
+	})
+	plist.ImportedType(func(t *TypeDeclaration) {})
+	plist.DocumentType(func(d *DocumentType) {
+		d.Name("Best App Document").
+			Role(RoleEditor).
+			HandlerRank(RankOwner).
+			ContentTypes("com.best.app.doc", "public.plain-text", "com.other.doc")
+	})
+	plist.DocumentType(func(d *DocumentType) {})
+
+	report := documentTypesReport(plist.documentTypes, plist.exportedTypes, plist.importedTypes)
+
+	issues := []string{}
+	for _, issue := range report.Errors() {
+		issues = append(issues, issue.Path+" "+issue.Code)
+	}
+
+	assert.Equal(t, []string{
+		"ImportedTypes[0].ConformsTo[0] invalid-uti",
+		"ImportedTypes[0].ConformsTo[1] invalid-uti",
+		"ImportedTypes[0].Identifier duplicate-uti",
+		"ImportedTypes[1].Identifier missing-property",
+		"ImportedTypes[1].ConformsTo missing-property",
+		"DocumentTypes[1].Name missing-property",
+		"DocumentTypes[1].ContentTypes missing-property",
+	}, issues)
+
+	warnings := report.Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "DocumentTypes[0].ContentTypes[2]", warnings[0].Path)
+	assert.Equal(t, CodeUndeclaredUTI, warnings[0].Code)
+
+	data := plist.build()
+	assert.Equal(t, map[string]interface{}{
+		"UTTypeIdentifier":  "com.best.app.doc",
+		"UTTypeDescription": "Best App Document",
+		"UTTypeConformsTo":  []string{"public.data", "public.content"},
+		"UTTypeTagSpecification": map[string]interface{}{
+			"public.filename-extension": []string{"bestdoc"},
+			"public.mime-type":          []string{"application/x-bestdoc"},
+		},
+	}, data["UTExportedTypeDeclarations"].([]map[string]interface{})[0])
+	assert.Equal(t, "Owner", data["CFBundleDocumentTypes"].([]map[string]interface{})[0]["LSHandlerRank"])
+}
@@ -0,0 +1,380 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for app extensions.
+const (
+	CodeInvalidActivationRule = "invalid-activation-rule"
+	CodeTruePredicate         = "true-predicate"
+)
+
+var (
+	// ErrInvalidActivationRule is the error returned for share extension
+	// activation rules that don't support any content.
+	ErrInvalidActivationRule = errors.New("Activation rule must support at least one type of content")
+
+	// ErrTruePredicate is the warning returned for share extensions using
+	// `TRUEPREDICATE`, which is rejected during App Review.
+	ErrTruePredicate = errors.New("TRUEPREDICATE activation rules are rejected during App Review")
+)
+
+// ExtensionPoint identifies the extension point an app extension supports.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionpointidentifier for more information.
+type ExtensionPoint string
+
+// Extension points supported by the `Extension` presets.
+const (
+	ExtensionPointWidget              ExtensionPoint = "com.apple.widgetkit-extension"
+	ExtensionPointShare               ExtensionPoint = "com.apple.share-services"
+	ExtensionPointAction              ExtensionPoint = "com.apple.ui-services"
+	ExtensionPointNotificationService ExtensionPoint = "com.apple.usernotifications.service"
+	ExtensionPointNotificationContent ExtensionPoint = "com.apple.usernotifications.content-extension"
+	ExtensionPointIntents             ExtensionPoint = "com.apple.intents-service"
+	ExtensionPointIntentsUI           ExtensionPoint = "com.apple.intents-ui-service"
+)
+
+// extensionRequirements describes the properties required by an extension
+// point.
+type extensionRequirements struct {
+	// entryPoint is true if the extension must specify a principal class or a
+	// main storyboard.
+	entryPoint bool
+
+	// attributes contains the required `NSExtensionAttributes` keys, mapped to
+	// the name of the function used to set them.
+	attributes []extensionAttribute
+}
+
+type extensionAttribute struct {
+	path, key string
+}
+
+var extensionPoints = map[ExtensionPoint]extensionRequirements{
+	ExtensionPointWidget: {},
+	ExtensionPointShare: {
+		entryPoint: true,
+		attributes: []extensionAttribute{{"ActivationRule", keyNSExtensionActivationRule}},
+	},
+	ExtensionPointAction: {
+		entryPoint: true,
+		attributes: []extensionAttribute{{"ActivationRule", keyNSExtensionActivationRule}},
+	},
+	ExtensionPointNotificationService: {entryPoint: true},
+	ExtensionPointNotificationContent: {
+		entryPoint: true,
+		attributes: []extensionAttribute{{"NotificationCategories", keyUNNotificationExtensionCategory}},
+	},
+	ExtensionPointIntents: {
+		entryPoint: true,
+		attributes: []extensionAttribute{{"IntentsSupported", keyIntentsSupported}},
+	},
+	ExtensionPointIntentsUI: {
+		entryPoint: true,
+		attributes: []extensionAttribute{{"IntentsSupported", keyIntentsSupported}},
+	},
+}
+
+// Extension describes the `NSExtension` dictionary of an app extension's
+// Info.plist. Use one of the presets, i.e. `Share()`, to populate the
+// extension point and the defaults Xcode uses for it.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension for more information.
+type Extension struct {
+	point          ExtensionPoint
+	principalClass string
+	mainStoryboard string
+	activationRule *ActivationRule
+	predicate      string
+	attributes     map[string]interface{}
+}
+
+// Apply will apply the extension to the specified property list dictionary.
+func (e *Extension) Apply(data map[string]interface{}) {
+	data[keyNSExtension] = e.build()
+}
+
+func (e *Extension) build() map[string]interface{} {
+	data := map[string]interface{}{
+		keyNSExtensionPointIdentifier: string(e.point),
+	}
+
+	if e.principalClass != "" {
+		data[keyNSExtensionPrincipalClass] = e.principalClass
+	}
+
+	if e.mainStoryboard != "" {
+		data[keyNSExtensionMainStoryboard] = e.mainStoryboard
+	}
+
+	if attributes := e.buildAttributes(); len(attributes) > 0 {
+		data[keyNSExtensionAttributes] = attributes
+	}
+
+	return data
+}
+
+func (e *Extension) buildAttributes() map[string]interface{} {
+	data := map[string]interface{}{}
+
+	for key, value := range e.attributes {
+		data[key] = value
+	}
+
+	if e.predicate != "" {
+		data[keyNSExtensionActivationRule] = e.predicate
+	} else if e.activationRule != nil {
+		data[keyNSExtensionActivationRule] = e.activationRule.build()
+	}
+
+	return data
+}
+
+// Validate will validate the extension and return any errors found.
+func (e *Extension) Validate() error {
+	return e.Report().Err()
+}
+
+// Report will validate the extension and return a report containing every
+// issue found.
+func (e *Extension) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if e.point == "" {
+		r.AddError("PointIdentifier", CodeMissingProperty, keyNSExtensionPointIdentifier, ErrMissingRequiredProperty)
+		return r
+	}
+
+	requirements := extensionPoints[e.point]
+
+	if requirements.entryPoint && e.principalClass == "" && e.mainStoryboard == "" {
+		r.AddError("PrincipalClass", CodeMissingProperty, keyNSExtensionPrincipalClass,
+			fmt.Errorf("%w: %v or %v", ErrMissingRequiredProperty, keyNSExtensionPrincipalClass, keyNSExtensionMainStoryboard))
+	}
+
+	attributes := e.buildAttributes()
+	for _, attribute := range requirements.attributes {
+		if _, found := attributes[attribute.key]; !found {
+			r.AddError(validation.Join("Attributes", attribute.path), CodeMissingProperty, attribute.key, ErrMissingRequiredProperty)
+		}
+	}
+
+	if strings.TrimSpace(e.predicate) == "TRUEPREDICATE" {
+		r.AddWarning("Attributes.ActivationRule", CodeTruePredicate, keyNSExtensionActivationRule, ErrTruePredicate)
+	} else if e.predicate == "" && e.activationRule != nil && len(e.activationRule.build()) == 0 {
+		r.AddError("Attributes.ActivationRule", CodeInvalidActivationRule, keyNSExtensionActivationRule, ErrInvalidActivationRule)
+	}
+
+	return r
+}
+
+// PointIdentifier specifies the extension point that the app extension
+// supports. Prefer one of the presets, i.e. `Share()`, where available.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionpointidentifier for more information.
+func (e *Extension) PointIdentifier(v ExtensionPoint) *Extension {
+	e.point = v
+	return e
+}
+
+// PrincipalClass specifies the name of the class that implements the app
+// extension's primary view controller or entry point, i.e.
+// `$(PRODUCT_MODULE_NAME).ShareViewController`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionprincipalclass for more information.
+func (e *Extension) PrincipalClass(v string) *Extension {
+	e.principalClass = v
+	return e
+}
+
+// MainStoryboard specifies the name of the app extension's main storyboard
+// file. Replaces `PrincipalClass` for storyboard based extensions.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionmainstoryboard for more information.
+func (e *Extension) MainStoryboard(v string) *Extension {
+	e.mainStoryboard = v
+	return e
+}
+
+// Attribute sets a custom `NSExtensionAttributes` key. This can be used if no
+// built-in method is provided for your key.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionattributes for more information.
+func (e *Extension) Attribute(key string, value interface{}) *Extension {
+	if e.attributes == nil {
+		e.attributes = map[string]interface{}{}
+	}
+
+	e.attributes[key] = value
+	return e
+}
+
+// Widget configures a WidgetKit extension.
+func (e *Extension) Widget() *Extension {
+	return e.PointIdentifier(ExtensionPointWidget)
+}
+
+// Share configures a share extension using the `MainInterface` storyboard. An
+// activation rule must be specified with `ActivationRule` or
+// `ActivationRulePredicate`.
+func (e *Extension) Share() *Extension {
+	return e.PointIdentifier(ExtensionPointShare).MainStoryboard("MainInterface")
+}
+
+// Action configures an action extension using the `MainInterface`
+// storyboard. An activation rule must be specified with `ActivationRule` or
+// `ActivationRulePredicate`.
+func (e *Extension) Action() *Extension {
+	return e.PointIdentifier(ExtensionPointAction).MainStoryboard("MainInterface")
+}
+
+// NotificationService configures a notification service extension using the
+// `$(PRODUCT_MODULE_NAME).NotificationService` principal class.
+func (e *Extension) NotificationService() *Extension {
+	return e.PointIdentifier(ExtensionPointNotificationService).
+		PrincipalClass("$(PRODUCT_MODULE_NAME).NotificationService")
+}
+
+// NotificationContent configures a notification content extension using the
+// `MainInterface` storyboard. The notification categories must be specified
+// with `NotificationCategories`.
+func (e *Extension) NotificationContent() *Extension {
+	e.PointIdentifier(ExtensionPointNotificationContent).MainStoryboard("MainInterface")
+	return e.Attribute(keyUNNotificationExtensionInitialContentSizeRatio, 1.0)
+}
+
+// Intents configures an intents extension using the
+// `$(PRODUCT_MODULE_NAME).IntentHandler` principal class. The supported
+// intents must be specified with `IntentsSupported`.
+func (e *Extension) Intents() *Extension {
+	return e.PointIdentifier(ExtensionPointIntents).
+		PrincipalClass("$(PRODUCT_MODULE_NAME).IntentHandler")
+}
+
+// IntentsUI configures an intents UI extension using the `MainInterface`
+// storyboard. The supported intents must be specified with `IntentsSupported`.
+func (e *Extension) IntentsUI() *Extension {
+	return e.PointIdentifier(ExtensionPointIntentsUI).MainStoryboard("MainInterface")
+}
+
+// ActivationRule specifies the types of content a share or action extension
+// supports.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionattributes/nsextensionactivationrule for more information.
+func (e *Extension) ActivationRule(f func(r *ActivationRule)) *Extension {
+	e.activationRule = &ActivationRule{}
+	f(e.activationRule)
+	return e
+}
+
+// ActivationRulePredicate specifies a predicate string describing the content
+// a share or action extension supports, replacing `ActivationRule`.
+// See https://developer.apple.com/library/archive/documentation/General/Conceptual/ExtensibilityPG/ExtensionScenarios.html#//apple_ref/doc/uid/TP40014214-CH21-SW8 for more information.
+func (e *Extension) ActivationRulePredicate(v string) *Extension {
+	e.predicate = v
+	return e
+}
+
+// IntentsSupported specifies the class names of the intents the extension
+// handles, i.e. `INSendMessageIntent`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionattributes/intentssupported for more information.
+func (e *Extension) IntentsSupported(intents ...string) *Extension {
+	return e.Attribute(keyIntentsSupported, intents)
+}
+
+// IntentsRestrictedWhileLocked specifies the class names of the intents that
+// require the device to be unlocked.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionattributes/intentsrestrictedwhilelocked for more information.
+func (e *Extension) IntentsRestrictedWhileLocked(intents ...string) *Extension {
+	return e.Attribute(keyIntentsRestrictedWhileLocked, intents)
+}
+
+// NotificationCategories specifies the notification categories handled by a
+// notification content extension.
+// See https://developer.apple.com/documentation/usernotificationsui/customizing_the_appearance_of_notifications for more information.
+func (e *Extension) NotificationCategories(categories ...string) *Extension {
+	if len(categories) == 1 {
+		return e.Attribute(keyUNNotificationExtensionCategory, categories[0])
+	}
+
+	return e.Attribute(keyUNNotificationExtensionCategory, categories)
+}
+
+// ActivationRule describes the content a share or action extension supports.
+// Every count specifies the maximum number of items of that type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsextension/nsextensionattributes/nsextensionactivationrule for more information.
+type ActivationRule struct {
+	text        bool
+	attachments int
+	files       int
+	images      int
+	movies      int
+	webURLs     int
+	webPages    int
+}
+
+func (r *ActivationRule) build() map[string]interface{} {
+	data := map[string]interface{}{}
+
+	if r.text {
+		data["NSExtensionActivationSupportsText"] = true
+	}
+
+	counts := map[string]int{
+		"NSExtensionActivationSupportsAttachmentsWithMaxCount": r.attachments,
+		"NSExtensionActivationSupportsFileWithMaxCount":        r.files,
+		"NSExtensionActivationSupportsImageWithMaxCount":       r.images,
+		"NSExtensionActivationSupportsMovieWithMaxCount":       r.movies,
+		"NSExtensionActivationSupportsWebURLWithMaxCount":      r.webURLs,
+		"NSExtensionActivationSupportsWebPageWithMaxCount":     r.webPages,
+	}
+
+	for key, count := range counts {
+		if count > 0 {
+			data[key] = count
+		}
+	}
+
+	return data
+}
+
+// Text specifies whether the extension supports text.
+func (r *ActivationRule) Text(v bool) *ActivationRule {
+	r.text = v
+	return r
+}
+
+// Attachments specifies the maximum number of attachments of any type.
+func (r *ActivationRule) Attachments(max int) *ActivationRule {
+	r.attachments = max
+	return r
+}
+
+// Files specifies the maximum number of files.
+func (r *ActivationRule) Files(max int) *ActivationRule {
+	r.files = max
+	return r
+}
+
+// Images specifies the maximum number of images.
+func (r *ActivationRule) Images(max int) *ActivationRule {
+	r.images = max
+	return r
+}
+
+// Movies specifies the maximum number of movies.
+func (r *ActivationRule) Movies(max int) *ActivationRule {
+	r.movies = max
+	return r
+}
+
+// WebURLs specifies the maximum number of web URLs.
+func (r *ActivationRule) WebURLs(max int) *ActivationRule {
+	r.webURLs = max
+	return r
+}
+
+// WebPages specifies the maximum number of web pages.
+func (r *ActivationRule) WebPages(max int) *ActivationRule {
+	r.webPages = max
+	return r
+}
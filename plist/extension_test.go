@@ -0,0 +1,141 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestExtension_Build(t *testing.T) {
+	type fields struct {
+		builder func() *PropertyList
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []string
+	}{
+		{
+			name: "Share extensions should not require app only properties",
+			fields: fields{
+				builder: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DevelopmentRegion("$(DEVELOPMENT_LANGUAGE)")
+					plist.BundleID("$(PRODUCT_BUNDLE_IDENTIFIER)")
+					plist.ExecutableFile("$(EXECUTABLE_NAME)")
+					plist.InfoDictionaryVersion("6.0")
+					plist.PackageType("$(PRODUCT_BUNDLE_PACKAGE_TYPE)")
+					plist.VersionShort("$(MARKETING_VERSION)")
+					plist.Version("1")
+					plist.BundleName("Share")
+					plist.DisplayName("Share")
+					plist.Extension(func(e *Extension) {
+						e.Share().ActivationRule(func(r *ActivationRule) {
+							r.Text(true).WebURLs(1)
+						})
+					})
+					return plist
+				},
+			},
+			want: []string{
+				"<key>NSExtensionPointIdentifier</key><string>com.apple.share-services</string>",
+				"<key>NSExtensionMainStoryboard</key><string>MainInterface</string>",
+				"<key>NSExtensionActivationSupportsText</key><true/>",
+				"<key>NSExtensionActivationSupportsWebURLWithMaxCount</key><integer>1</integer>",
+			},
+		},
+		{
+			name: "Widget extensions should only specify the extension point",
+			fields: fields{
+				builder: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DevelopmentRegion("$(DEVELOPMENT_LANGUAGE)")
+					plist.BundleID("$(PRODUCT_BUNDLE_IDENTIFIER)")
+					plist.ExecutableFile("$(EXECUTABLE_NAME)")
+					plist.InfoDictionaryVersion("6.0")
+					plist.PackageType("$(PRODUCT_BUNDLE_PACKAGE_TYPE)")
+					plist.VersionShort("$(MARKETING_VERSION)")
+					plist.Version("1")
+					plist.BundleName("Widget")
+					plist.DisplayName("Widget")
+					plist.Extension(func(e *Extension) {
+						e.Widget()
+					})
+					return plist
+				},
+			},
+			want: []string{
+				"<key>NSExtension</key><dict><key>NSExtensionPointIdentifier</key><string>com.apple.widgetkit-extension</string></dict>",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := tt.fields.builder()
+			assert.Nil(t, plist.Validate())
+
+			out, err := plist.Build()
+			assert.Nil(t, err)
+
+			for _, want := range tt.want {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+}
+
+func TestExtension_Report(t *testing.T) {
+	e := &Extension{}
+	issues := e.Report().Errors()
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "PointIdentifier", issues[0].Path)
+
+	e.Share()
+	issues = e.Report().Errors()
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "Attributes.ActivationRule", issues[0].Path)
+	assert.Equal(t, keyNSExtensionActivationRule, issues[0].Key)
+
+	e.ActivationRule(func(r *ActivationRule) {})
+	issues = e.Report().Errors()
+	assert.Len(t, issues, 1)
+	assert.True(t, errors.Is(issues[0], ErrInvalidActivationRule))
+
+	e.ActivationRulePredicate("TRUEPREDICATE")
+	report := e.Report()
+	assert.Nil(t, report.Err())
+	assert.Len(t, report.Warnings(), 1)
+	assert.Equal(t, CodeTruePredicate, report.Warnings()[0].Code)
+
+	e = &Extension{}
+	e.Intents().PrincipalClass("")
+	issues = e.Report().Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "PrincipalClass", issues[0].Path)
+	assert.Equal(t, "Attributes.IntentsSupported", issues[1].Path)
+
+	e.PrincipalClass("IntentHandler").IntentsSupported("INSendMessageIntent")
+	assert.Nil(t, e.Validate())
+}
+
+func TestExtension_Presets(t *testing.T) {
+	widget := (&Extension{}).Widget()
+	assert.Nil(t, widget.Validate())
+	assert.Equal(t, map[string]interface{}{
+		"NSExtensionPointIdentifier": "com.apple.widgetkit-extension",
+	}, widget.build())
+
+	service := (&Extension{}).NotificationService()
+	assert.Nil(t, service.Validate())
+	assert.Equal(t, "$(PRODUCT_MODULE_NAME).NotificationService", service.build()["NSExtensionPrincipalClass"])
+
+	content := (&Extension{}).NotificationContent()
+	assert.NotNil(t, content.Validate())
+	content.NotificationCategories("message")
+	assert.Nil(t, content.Validate())
+	assert.Equal(t, map[string]interface{}{
+		"UNNotificationExtensionCategory":                "message",
+		"UNNotificationExtensionInitialContentSizeRatio": 1.0,
+	}, content.build()["NSExtensionAttributes"])
+}
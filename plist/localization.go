@@ -0,0 +1,297 @@
+package plist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for localizations.
+const (
+	CodeInvalidLocale      = "invalid-locale"
+	CodeMissingTranslation = "missing-translation"
+	CodeUnusedTranslation  = "unused-translation"
+)
+
+var (
+	// ErrInvalidLocale is the error returned for malformed locale identifiers.
+	ErrInvalidLocale = errors.New("Locale must be a language code optionally followed by a script and/or region, i.e. pt-BR")
+
+	// ErrMissingTranslation is the error returned when a locale does not
+	// translate a localized key.
+	ErrMissingTranslation = errors.New("Missing translation")
+
+	// ErrUnusedTranslation is the warning returned when a locale translates a
+	// key the property list does not specify.
+	ErrUnusedTranslation = errors.New("Translation is not used by the property list")
+
+	localeRegex = regexp.MustCompile(`^([a-z]{2,3}([-_][A-Za-z0-9]{2,8})*|Base)$`)
+)
+
+const (
+	infoPlistStrings = "InfoPlist.strings"
+	infoPlistCatalog = "InfoPlist.xcstrings"
+)
+
+// Translation is a property list value translated for a locale.
+type Translation struct {
+	Locale string
+	Value  string
+}
+
+// Translate returns the translation of a value for a locale, i.e. `fr` or
+// `pt-BR`.
+func Translate(locale, value string) Translation {
+	return Translation{Locale: locale, Value: value}
+}
+
+// Localizations returns the localized property list values of every locale,
+// keyed by locale and property list key.
+func (p *PropertyList) Localizations() map[string]map[string]string {
+	localizations := map[string]map[string]string{}
+
+	locale := func(l string) map[string]string {
+		if _, found := localizations[l]; !found {
+			localizations[l] = map[string]string{}
+		}
+
+		return localizations[l]
+	}
+
+	for l, name := range p.localizedDisplayNames {
+		locale(l)[keyCFBundleDisplayName] = name
+	}
+
+	if privacy := p.privacy; privacy != nil {
+		for l, translations := range privacy.locales {
+			values := locale(l)
+			for key, value := range translations.values {
+				values[key] = fmt.Sprint(value)
+			}
+		}
+	}
+
+	return localizations
+}
+
+// localizedKeys returns the keys every locale must translate.
+func (p *PropertyList) localizedKeys() []string {
+	keys := []string{}
+
+	if len(p.localizedDisplayNames) > 0 {
+		keys = append(keys, keyCFBundleDisplayName)
+	}
+
+	if privacy := p.privacy; privacy != nil && len(privacy.locales) > 0 {
+		keys = append(keys, sortedKeys(privacy.values)...)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// localizationReport validates that every locale translates every localized
+// key.
+func (p *PropertyList) localizationReport() *validation.Report {
+	r := &validation.Report{}
+
+	localizations := p.Localizations()
+	keys := p.localizedKeys()
+
+	required := map[string]bool{}
+	for _, key := range keys {
+		required[key] = true
+	}
+
+	for _, locale := range sortedLocales(localizations) {
+		path := fmt.Sprintf("Localizations[%v]", locale)
+		values := localizations[locale]
+
+		if !localeRegex.MatchString(locale) {
+			r.AddError(path, CodeInvalidLocale, "", fmt.Errorf("%w: %q", ErrInvalidLocale, locale))
+		}
+
+		for _, key := range keys {
+			if _, found := values[key]; !found {
+				r.AddError(path, CodeMissingTranslation, key, ErrMissingTranslation)
+			}
+		}
+
+		for _, key := range sortedStrings(values) {
+			if !required[key] {
+				r.AddWarning(path, CodeUnusedTranslation, key, ErrUnusedTranslation)
+			}
+		}
+	}
+
+	return r
+}
+
+// LocalizedStrings returns the contents of the `InfoPlist.strings` file of
+// every locale, keyed by their path, i.e. `fr.lproj/InfoPlist.strings`.
+func (p *PropertyList) LocalizedStrings() map[string]string {
+	files := map[string]string{}
+
+	for locale, values := range p.Localizations() {
+		lines := []string{}
+		for _, key := range sortedStrings(values) {
+			lines = append(lines, fmt.Sprintf("\"%v\" = \"%v\";", key, escapeStrings(values[key])))
+		}
+
+		path := filepath.Join(locale+".lproj", infoPlistStrings)
+		files[path] = strings.Join(lines, "\n") + "\n"
+	}
+
+	return files
+}
+
+// StringCatalog returns the localized values of every locale as an
+// `InfoPlist.xcstrings` string catalog.
+// See https://developer.apple.com/documentation/xcode/localizing-and-varying-text-with-a-string-catalog for more information.
+func (p *PropertyList) StringCatalog() (string, error) {
+	type stringUnit struct {
+		State string `json:"state"`
+		Value string `json:"value"`
+	}
+
+	type localization struct {
+		StringUnit stringUnit `json:"stringUnit"`
+	}
+
+	type entry struct {
+		ExtractionState string                  `json:"extractionState"`
+		Localizations   map[string]localization `json:"localizations"`
+	}
+
+	entries := map[string]*entry{}
+
+	for locale, values := range p.Localizations() {
+		for key, value := range values {
+			if _, found := entries[key]; !found {
+				entries[key] = &entry{
+					ExtractionState: "manual",
+					Localizations:   map[string]localization{},
+				}
+			}
+
+			entries[key].Localizations[locale] = localization{
+				StringUnit: stringUnit{State: "translated", Value: value},
+			}
+		}
+	}
+
+	catalog := struct {
+		SourceLanguage string            `json:"sourceLanguage"`
+		Strings        map[string]*entry `json:"strings"`
+		Version        string            `json:"version"`
+	}{
+		SourceLanguage: p.sourceLanguage(),
+		Strings:        entries,
+		Version:        "1.0",
+	}
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data) + "\n", nil
+}
+
+// sourceLanguage returns the development region, or `en` if it is unset or a
+// build setting such as `$(DEVELOPMENT_LANGUAGE)`.
+func (p *PropertyList) sourceLanguage() string {
+	if p.developmentRegion == "" || strings.HasPrefix(p.developmentRegion, "$(") {
+		return "en"
+	}
+
+	return p.developmentRegion
+}
+
+// SaveLocalizations validates the localizations and writes them to the
+// specified folder, typically the folder containing the Info.plist. When
+// `catalog` is true a single `InfoPlist.xcstrings` string catalog is written,
+// otherwise a `<locale>.lproj/InfoPlist.strings` file is written per locale.
+func (p *PropertyList) SaveLocalizations(path string, catalog bool) error {
+	return p.SaveLocalizationsFS(filesystem.OSFileSystem{}, path, catalog)
+}
+
+// SaveLocalizationsFS validates the localizations and writes them to the
+// specified folder within the provided file system, see `SaveLocalizations`.
+func (p *PropertyList) SaveLocalizationsFS(fsys filesystem.FileSystem, path string, catalog bool) error {
+	if err := p.localizationReport().Err(); err != nil {
+		return err
+	}
+
+	files := p.LocalizedStrings()
+	if catalog {
+		data, err := p.StringCatalog()
+		if err != nil {
+			return err
+		}
+
+		files = map[string]string{infoPlistCatalog: data}
+	}
+
+	for _, name := range sortedStrings(files) {
+		file := filepath.Join(path, name)
+
+		if err := fsys.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			return fmt.Errorf("Unable to create %v: %w", filepath.Dir(file), err)
+		}
+
+		if err := fsys.WriteFile(file, []byte(files[name]), 0644); err != nil {
+			return fmt.Errorf("Unable to write %v: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// escapeStrings escapes a value for use in a `.strings` file.
+func escapeStrings(v string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return replacer.Replace(v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedStrings(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func sortedLocales(m map[string]map[string]string) []string {
+	locales := make([]string, 0, len(m))
+	for locale := range m {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
@@ -0,0 +1,267 @@
+package plist
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_LocalizedStrings(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   map[string]string
+	}{
+		{
+			name: "Translated display names and privacy descriptions should be written per locale",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DisplayName("Best App", Translate("fr", "Meilleure App"))
+					plist.Privacy(func(p *Privacy) {
+						p.Camera("We use the camera to scan receipts")
+						p.Localize("fr", func(l *Privacy) {
+							l.Camera("Nous utilisons l'appareil \"photo\"")
+						})
+					})
+
+					return plist
+				},
+			},
+			want: map[string]string{
+				filepath.Join("fr.lproj", "InfoPlist.strings"): `"CFBundleDisplayName" = "Meilleure App";
+"NSCameraUsageDescription" = "Nous utilisons l'appareil \"photo\"";
+`,
+			},
+		},
+		{
+			name: "Setting the display name again should replace its translations",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DisplayName("Best App", Translate("fr", "Meilleure App"))
+					plist.DisplayName("Best App", Translate("de", "Beste App"))
+
+					return plist
+				},
+			},
+			want: map[string]string{
+				filepath.Join("de.lproj", "InfoPlist.strings"): `"CFBundleDisplayName" = "Beste App";
+`,
+			},
+		},
+		{
+			name: "A property list without translations should have no localizations",
+			fields: fields{
+				plist: func() *PropertyList {
+					return New(PlatformIOS).DisplayName("Best App")
+				},
+			},
+			want: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := tt.fields.plist()
+			assert.True(t, plist.localizationReport().Empty())
+			assert.Equal(t, tt.want, plist.LocalizedStrings())
+		})
+	}
+}
+
+func TestPropertyList_LocalizationReport(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "Complete translations should not report any issues",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DisplayName("Best App", Translate("fr", "Meilleure App"))
+					plist.Privacy(func(p *Privacy) {
+						p.Camera("We use the camera to scan receipts")
+						p.Localize("fr", func(l *Privacy) {
+							l.Camera("Nous utilisons l'appareil photo")
+						})
+					})
+
+					return plist
+				},
+			},
+			errors:   []string{},
+			warnings: []string{},
+		},
+		{
+			name: "Missing translations, invalid locales and extra keys should be reported",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DisplayName("Best App",
+						Translate("fr", "Meilleure App"),
+						Translate("es", "La Mejor App"),
+					)
+					plist.Privacy(func(p *Privacy) {
+						p.Camera("We use the camera to scan receipts")
+						p.Contacts("We use your contacts to find friends")
+						p.Localize("fr", func(l *Privacy) {
+							l.Camera("Nous utilisons l'appareil photo")
+							l.Contacts("Nous utilisons vos contacts")
+							l.Microphone("Nous utilisons le micro")
+						})
+						p.Localize("english", func(l *Privacy) {
+							l.Camera("We use the camera to scan receipts")
+							l.Contacts("We use your contacts to find friends")
+						})
+					})
+
+					return plist
+				},
+			},
+			errors: []string{
+				"Localizations[english] invalid-locale ",
+				"Localizations[english] missing-translation CFBundleDisplayName",
+				"Localizations[es] missing-translation NSCameraUsageDescription",
+				"Localizations[es] missing-translation NSContactsUsageDescription",
+			},
+			warnings: []string{
+				"Localizations[fr] NSMicrophoneUsageDescription",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plist := tt.fields.plist()
+			report := plist.localizationReport()
+
+			errors := []string{}
+			for _, issue := range report.Errors() {
+				errors = append(errors, issue.Path+" "+issue.Code+" "+issue.Key)
+			}
+
+			warnings := []string{}
+			for _, issue := range report.Warnings() {
+				warnings = append(warnings, issue.Path+" "+issue.Key)
+			}
+
+			assert.Equal(t, tt.errors, errors)
+			assert.Equal(t, tt.warnings, warnings)
+
+			err := plist.SaveLocalizationsFS(filesystem.NewMemoryFileSystem(), "", false)
+			if len(tt.errors) > 0 {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestPropertyList_SaveLocalizationsFS(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		catalog bool
+		want    map[string]string
+	}{
+		{
+			name: "Saving without a catalog should write a strings file per locale",
+			fields: fields{
+				plist: func() *PropertyList {
+					return New(PlatformIOS).DisplayName("Best App",
+						Translate("fr", "Meilleure App"),
+						Translate("de", "Beste App"),
+					)
+				},
+			},
+			want: map[string]string{
+				"App/de.lproj/InfoPlist.strings": `"CFBundleDisplayName" = "Beste App";
+`,
+				"App/fr.lproj/InfoPlist.strings": `"CFBundleDisplayName" = "Meilleure App";
+`,
+			},
+		},
+		{
+			name: "Saving with a catalog should write a single string catalog",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformIOS)
+					plist.DisplayName("Best App",
+						Translate("fr", "Meilleure App"),
+						Translate("de", "Beste App"),
+					)
+					plist.Privacy(func(p *Privacy) {
+						p.Camera("We use the camera to scan receipts")
+						p.Localize("fr", func(l *Privacy) {
+							l.Camera("Nous utilisons l'appareil photo")
+						})
+						p.Localize("de", func(l *Privacy) {
+							l.Camera("Wir verwenden die Kamera")
+						})
+					})
+
+					return plist
+				},
+			},
+			catalog: true,
+			want: map[string]string{
+				"App/InfoPlist.xcstrings": `{
+					"sourceLanguage": "en",
+					"strings": {
+						"CFBundleDisplayName": {
+							"extractionState": "manual",
+							"localizations": {
+								"de": {"stringUnit": {"state": "translated", "value": "Beste App"}},
+								"fr": {"stringUnit": {"state": "translated", "value": "Meilleure App"}}
+							}
+						},
+						"NSCameraUsageDescription": {
+							"extractionState": "manual",
+							"localizations": {
+								"de": {"stringUnit": {"state": "translated", "value": "Wir verwenden die Kamera"}},
+								"fr": {"stringUnit": {"state": "translated", "value": "Nous utilisons l'appareil photo"}}
+							}
+						}
+					},
+					"version": "1.0"
+				}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			assert.Nil(t, tt.fields.plist().SaveLocalizationsFS(fsys, "App", tt.catalog))
+
+			files := []string{}
+			for name, want := range tt.want {
+				files = append(files, name)
+
+				data, err := fsys.ReadFile(name)
+				assert.Nil(t, err)
+
+				if tt.catalog {
+					assert.JSONEq(t, want, string(data))
+				} else {
+					assert.Equal(t, want, string(data))
+				}
+			}
+
+			assert.ElementsMatch(t, files, fsys.Files())
+		})
+	}
+}
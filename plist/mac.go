@@ -0,0 +1,110 @@
+package plist
+
+// ApplicationCategory is a Uniform Type Identifier describing the App Store
+// category of a macOS app.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/lsapplicationcategorytype for more information.
+type ApplicationCategory string
+
+// App Store categories for `PropertyList.ApplicationCategory`.
+const (
+	CategoryBusiness          ApplicationCategory = "public.app-category.business"
+	CategoryDeveloperTools    ApplicationCategory = "public.app-category.developer-tools"
+	CategoryEducation         ApplicationCategory = "public.app-category.education"
+	CategoryEntertainment     ApplicationCategory = "public.app-category.entertainment"
+	CategoryFinance           ApplicationCategory = "public.app-category.finance"
+	CategoryGames             ApplicationCategory = "public.app-category.games"
+	CategoryGraphicsDesign    ApplicationCategory = "public.app-category.graphics-design"
+	CategoryHealthcareFitness ApplicationCategory = "public.app-category.healthcare-fitness"
+	CategoryLifestyle         ApplicationCategory = "public.app-category.lifestyle"
+	CategoryMedical           ApplicationCategory = "public.app-category.medical"
+	CategoryMusic             ApplicationCategory = "public.app-category.music"
+	CategoryNews              ApplicationCategory = "public.app-category.news"
+	CategoryPhotography       ApplicationCategory = "public.app-category.photography"
+	CategoryProductivity      ApplicationCategory = "public.app-category.productivity"
+	CategoryReference         ApplicationCategory = "public.app-category.reference"
+	CategorySocialNetworking  ApplicationCategory = "public.app-category.social-networking"
+	CategorySports            ApplicationCategory = "public.app-category.sports"
+	CategoryTravel            ApplicationCategory = "public.app-category.travel"
+	CategoryUtilities         ApplicationCategory = "public.app-category.utilities"
+	CategoryVideo             ApplicationCategory = "public.app-category.video"
+	CategoryWeather           ApplicationCategory = "public.app-category.weather"
+	CategoryActionGames       ApplicationCategory = "public.app-category.action-games"
+	CategoryAdventureGames    ApplicationCategory = "public.app-category.adventure-games"
+	CategoryArcadeGames       ApplicationCategory = "public.app-category.arcade-games"
+	CategoryBoardGames        ApplicationCategory = "public.app-category.board-games"
+	CategoryCardGames         ApplicationCategory = "public.app-category.card-games"
+	CategoryCasinoGames       ApplicationCategory = "public.app-category.casino-games"
+	CategoryDiceGames         ApplicationCategory = "public.app-category.dice-games"
+	CategoryEducationalGames  ApplicationCategory = "public.app-category.educational-games"
+	CategoryFamilyGames       ApplicationCategory = "public.app-category.family-games"
+	CategoryKidsGames         ApplicationCategory = "public.app-category.kids-games"
+	CategoryMusicGames        ApplicationCategory = "public.app-category.music-games"
+	CategoryPuzzleGames       ApplicationCategory = "public.app-category.puzzle-games"
+	CategoryRacingGames       ApplicationCategory = "public.app-category.racing-games"
+	CategoryRolePlayingGames  ApplicationCategory = "public.app-category.role-playing-games"
+	CategorySimulationGames   ApplicationCategory = "public.app-category.simulation-games"
+	CategorySportsGames       ApplicationCategory = "public.app-category.sports-games"
+	CategoryStrategyGames     ApplicationCategory = "public.app-category.strategy-games"
+	CategoryTriviaGames       ApplicationCategory = "public.app-category.trivia-games"
+	CategoryWordGames         ApplicationCategory = "public.app-category.word-games"
+)
+
+// MinimumSystemVersion specifies the minimum version of macOS required for the
+// app to run, i.e. `$(MACOSX_DEPLOYMENT_TARGET)`. Required on macOS.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/lsminimumsystemversion for more information.
+func (p *PropertyList) MinimumSystemVersion(v string) *PropertyList {
+	p.touch(keyLSMinimumSystemVersion)
+	p.minimumSystemVersion = v
+	return p
+}
+
+// PrincipalClass specifies the name of the bundle’s main executable class,
+// i.e. `NSApplication`. Required on macOS.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsprincipalclass for more information.
+func (p *PropertyList) PrincipalClass(v string) *PropertyList {
+	p.touch(keyNSPrincipalClass)
+	p.principalClass = v
+	return p
+}
+
+// ApplicationCategory specifies the App Store category that best describes
+// your app.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/lsapplicationcategorytype for more information.
+func (p *PropertyList) ApplicationCategory(v ApplicationCategory) *PropertyList {
+	p.touch(keyLSApplicationCategoryType)
+	p.applicationCategory = v
+	return p
+}
+
+// Copyright specifies a human-readable copyright notice for the bundle.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nshumanreadablecopyright for more information.
+func (p *PropertyList) Copyright(v string) *PropertyList {
+	p.touch(keyNSHumanReadableCopyright)
+	p.copyright = v
+	return p
+}
+
+// MainNibFile specifies the name of the app’s main nib file.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/nsmainnibfile for more information.
+func (p *PropertyList) MainNibFile(v string) *PropertyList {
+	p.touch(keyNSMainNibFile)
+	p.mainNibFile = v
+	return p
+}
+
+// applyMac applies any macOS specific properties that have been specified.
+func (p *PropertyList) applyMac(data map[string]interface{}) {
+	values := map[string]string{
+		keyLSMinimumSystemVersion:    p.minimumSystemVersion,
+		keyNSPrincipalClass:          p.principalClass,
+		keyLSApplicationCategoryType: string(p.applicationCategory),
+		keyNSHumanReadableCopyright:  p.copyright,
+		keyNSMainNibFile:             p.mainNibFile,
+	}
+
+	for key, value := range values {
+		if value != "" {
+			data[key] = value
+		}
+	}
+}
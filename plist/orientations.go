@@ -14,9 +14,10 @@ func (d *Orientations) Validate() error {
 	return nil
 }
 
-// Apply will apply the interface orientations to the Propertylist.
-func (d *Orientations) Apply(p *PropertyList, modifier string) {
-	p.data["UISupportedInterfaceOrientations"+modifier] = d.orientations
+// Apply will apply the interface orientations to the property list
+// dictionary.
+func (d *Orientations) Apply(data map[string]interface{}, modifier string) {
+	data["UISupportedInterfaceOrientations"+modifier] = d.orientations
 }
 
 // Portrait specifies the portrait device orientation.
@@ -0,0 +1,243 @@
+package plist
+
+// platformProperty describes a property list key that is required or
+// forbidden on a platform. `path` is the name of the builder function used to
+// set the property.
+//
+// Required properties use `missing` to check whether the property has been
+// specified. Forbidden properties are detected by looking for `key` in the
+// built property list, unless `set` is provided.
+type platformProperty struct {
+	path    string
+	key     string
+	missing func(p *PropertyList) bool
+	set     func(p *PropertyList) bool
+}
+
+// platformRules contains the defaults and validation rules for a platform.
+type platformRules struct {
+	name string
+
+	// requiresIPhoneOS is true for platforms that always include the
+	// `LSRequiresIPhoneOS` key.
+	requiresIPhoneOS bool
+
+	// statusBar is true for platforms that always include the status bar keys.
+	statusBar bool
+
+	// displayName is true for platforms that always include the
+	// `CFBundleDisplayName` key.
+	displayName bool
+
+	// defaults applies the platform specific defaults, see `Defaults`.
+	defaults func(p *PropertyList)
+
+	required  []platformProperty
+	forbidden []platformProperty
+
+	// appRequired contains the properties that are only required for apps and
+	// not for app extensions.
+	appRequired []platformProperty
+}
+
+var commonRequiredProperties = []platformProperty{
+	{"BundleID", keyCFBundleIdentifier, func(p *PropertyList) bool { return p.bundleIdentifier == "" }, nil},
+	{"BundleName", keyCFBundleName, func(p *PropertyList) bool { return p.bundleName == "" }, nil},
+}
+
+var bundleRequiredProperties = []platformProperty{
+	{"DevelopmentRegion", keyCFBundleDevelopmentRegion, func(p *PropertyList) bool { return p.developmentRegion == "" }, nil},
+	{"ExecutableFile", keyCFBundleExecutable, func(p *PropertyList) bool { return p.executableFile == "" }, nil},
+	{"InfoDictionaryVersion", keyCFBundleInfoDictionaryVersion, func(p *PropertyList) bool { return p.version == "" }, nil},
+	{"PackageType", keyCFBundlePackageType, func(p *PropertyList) bool { return p.packageType == "" }, nil},
+	{"VersionShort", keyCFBundleShortVersionString, func(p *PropertyList) bool { return p.applicationVersionShort == "" }, nil},
+	{"Version", keyCFBundleVersion, func(p *PropertyList) bool { return p.bundleVersion == "" }, nil},
+}
+
+func properties(groups ...[]platformProperty) []platformProperty {
+	all := []platformProperty{}
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+
+	return all
+}
+
+var statusBarProperties = []platformProperty{
+	{path: "StatusBarStyle", key: keyUIStatusBarStyle},
+	{path: "StatusBarHidden", key: keyUIStatusBarHidden},
+}
+
+var watchProperties = []platformProperty{
+	{path: "WatchApplication", key: keyWKApplication},
+	{path: "CompanionAppBundleID", key: keyWKCompanionAppBundleIdentifier},
+	{path: "RunsIndependentlyOfCompanionApp", key: keyWKRunsIndependentlyOfCompanionApp},
+}
+
+var tvProperties = []platformProperty{
+	{path: "SupportsHDR", key: keyUIAppSupportsHDR},
+}
+
+var visionProperties = []platformProperty{
+	{
+		path: "SceneManifest.PreferredDefaultSessionRole",
+		key:  keyUIApplicationPreferredDefaultSceneSessionRole,
+		set:  func(p *PropertyList) bool { return p.scene != nil && p.scene.preferredRole != "" },
+	},
+	{
+		path: "SceneManifest.Volumetric",
+		key:  string(SceneSessionRoleVolumetric),
+		set:  func(p *PropertyList) bool { return p.scene != nil && p.scene.volumetric != nil },
+	},
+	{
+		path: "SceneManifest.ImmersiveSpace",
+		key:  string(SceneSessionRoleImmersiveSpace),
+		set:  func(p *PropertyList) bool { return p.scene != nil && p.scene.immersiveSpace != nil },
+	},
+}
+
+var platforms = map[Platform]platformRules{
+	PlatformIOS: {
+		name:             "iOS",
+		requiresIPhoneOS: true,
+		statusBar:        true,
+		displayName:      true,
+		defaults: func(p *PropertyList) {
+			p.RequiresIOS()
+			p.MainStoryboard("Main")
+			p.ViewControllerBasedStatusBarAppearance(true)
+			p.StatusBarStyleDefault()
+			p.StatusBarHidden(false)
+			p.Capabilities(func(c *DeviceCapabilities) {
+				c.ARMv7()
+			})
+			p.AppTransportSecurity(func(s *AppTransportSecurity) {
+				s.AllowArbitraryLoads(true)
+			})
+			p.Orientations(func(o *Orientations) {
+				o.Portrait()
+			})
+			// TODO: Scene manifest struct?
+		},
+		required: properties(
+			commonRequiredProperties,
+			[]platformProperty{
+				{"DisplayName", keyCFBundleDisplayName, func(p *PropertyList) bool { return p.displayName == "" }, nil},
+			},
+			bundleRequiredProperties,
+		),
+		appRequired: []platformProperty{
+			{"StatusBarStyle", keyUIStatusBarStyle, func(p *PropertyList) bool { return p.statusBarStyle == "" }, nil},
+			{"AppTransportSecurity", keyNSAppTransportSecurity, func(p *PropertyList) bool { return p.ats == nil }, nil},
+			{"SceneManifest", keyUIApplicationSceneManifest, func(p *PropertyList) bool { return p.scene == nil }, nil},
+		},
+		forbidden: properties(watchProperties, tvProperties, visionProperties),
+	},
+	PlatformMac: {
+		name: "macOS",
+		defaults: func(p *PropertyList) {
+			p.MinimumSystemVersion("$(MACOSX_DEPLOYMENT_TARGET)")
+			p.PrincipalClass("NSApplication")
+		},
+		required: properties(
+			commonRequiredProperties,
+			bundleRequiredProperties,
+			[]platformProperty{
+				{"MinimumSystemVersion", keyLSMinimumSystemVersion, func(p *PropertyList) bool { return p.minimumSystemVersion == "" }, nil},
+			},
+		),
+		appRequired: []platformProperty{
+			{"PrincipalClass", keyNSPrincipalClass, func(p *PropertyList) bool { return p.principalClass == "" }, nil},
+		},
+		forbidden: properties(
+			[]platformProperty{{path: "RequiresIOS", key: keyLSRequiresIPhoneOS}},
+			statusBarProperties,
+			[]platformProperty{
+				{path: "SceneManifest", key: keyUIApplicationSceneManifest},
+				{path: "Orientations", key: keyUISupportedInterfaceOrientations},
+				{path: "TabletOrientations", key: keyUISupportedInterfaceOrientationsIPad},
+				{path: "Capabilities", key: keyUIRequiredDeviceCapabilities},
+			},
+			watchProperties,
+			tvProperties,
+		),
+	},
+	PlatformWatch: {
+		name:        "watchOS",
+		displayName: true,
+		defaults: func(p *PropertyList) {
+			p.WatchApplication(true)
+		},
+		required: properties(
+			commonRequiredProperties,
+			[]platformProperty{
+				{"DisplayName", keyCFBundleDisplayName, func(p *PropertyList) bool { return p.displayName == "" }, nil},
+			},
+			bundleRequiredProperties,
+		),
+		appRequired: []platformProperty{
+			{"WatchApplication", keyWKApplication, func(p *PropertyList) bool { return !p.watchApplication }, nil},
+		},
+		forbidden: properties(
+			[]platformProperty{{path: "RequiresIOS", key: keyLSRequiresIPhoneOS}},
+			statusBarProperties,
+			[]platformProperty{
+				{path: "SceneManifest", key: keyUIApplicationSceneManifest},
+				{path: "TabletOrientations", key: keyUISupportedInterfaceOrientationsIPad},
+			},
+			tvProperties,
+		),
+	},
+	PlatformTV: {
+		name:             "tvOS",
+		requiresIPhoneOS: true,
+		defaults: func(p *PropertyList) {
+			p.RequiresIOS()
+			p.MainStoryboard("Main")
+			p.Capabilities(func(c *DeviceCapabilities) {
+				c.ARM64()
+			})
+		},
+		required: properties(commonRequiredProperties, bundleRequiredProperties),
+		forbidden: properties(
+			statusBarProperties,
+			[]platformProperty{
+				{path: "Orientations", key: keyUISupportedInterfaceOrientations},
+				{path: "TabletOrientations", key: keyUISupportedInterfaceOrientationsIPad},
+			},
+			watchProperties,
+			visionProperties,
+		),
+	},
+	PlatformVision: {
+		name: "visionOS",
+		defaults: func(p *PropertyList) {
+			p.SceneManifest(func(m *SceneManifest) {
+				m.MultipleWindows(true)
+				m.PreferredDefaultSessionRole(SceneSessionRoleApplication)
+			})
+		},
+		required: properties(commonRequiredProperties, bundleRequiredProperties),
+		appRequired: []platformProperty{
+			{"SceneManifest", keyUIApplicationSceneManifest, func(p *PropertyList) bool { return p.scene == nil }, nil},
+		},
+		forbidden: properties(
+			statusBarProperties,
+			[]platformProperty{
+				{path: "TabletOrientations", key: keyUISupportedInterfaceOrientationsIPad},
+			},
+			watchProperties,
+			tvProperties,
+		),
+	},
+}
+
+// rules returns the validation rules for the property list's platform. If no
+// platform has been specified the iOS rules are used.
+func (p *PropertyList) rules() platformRules {
+	if rules, ok := platforms[p.platform]; ok {
+		return rules
+	}
+
+	return platforms[PlatformIOS]
+}
@@ -0,0 +1,153 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_MacDefaults(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.ApplicationCategory(CategoryDeveloperTools)
+	plist.Copyright("Copyright © 2020 Best App")
+
+	assert.Nil(t, plist.Validate())
+
+	out, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>LSMinimumSystemVersion</key><string>$(MACOSX_DEPLOYMENT_TARGET)</string>")
+	assert.Contains(t, out, "<key>NSPrincipalClass</key><string>NSApplication</string>")
+	assert.Contains(t, out, "<key>LSApplicationCategoryType</key><string>public.app-category.developer-tools</string>")
+	assert.NotContains(t, out, "LSRequiresIPhoneOS")
+	assert.NotContains(t, out, "UIStatusBarStyle")
+	assert.NotContains(t, out, "CFBundleDisplayName")
+}
+
+func TestPropertyList_MacValidation(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.PrincipalClass("")
+	plist.StatusBarStyleDefault()
+	plist.Orientations(func(o *Orientations) {
+		o.Portrait()
+	})
+
+	report := plist.Report()
+	issues := report.Errors()
+	assert.Len(t, issues, 3)
+
+	assert.Equal(t, "PrincipalClass", issues[0].Path)
+	assert.Equal(t, CodeMissingProperty, issues[0].Code)
+
+	assert.Equal(t, "UIStatusBarStyle", issues[1].Key)
+	assert.Equal(t, CodeForbiddenProperty, issues[1].Code)
+	assert.True(t, errors.Is(issues[1], ErrForbiddenProperty))
+
+	assert.Equal(t, "UISupportedInterfaceOrientations", issues[2].Key)
+
+}
+
+func TestPropertyList_IOSIgnoresMacRules(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Defaults()
+
+	for _, issue := range plist.Report().Errors() {
+		assert.NotEqual(t, "MinimumSystemVersion", issue.Path)
+		assert.NotEqual(t, "PrincipalClass", issue.Path)
+	}
+}
+
+func TestPropertyList_WatchDefaults(t *testing.T) {
+	plist := New(PlatformWatch)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.DisplayName("Best App")
+	plist.CompanionAppBundleID("com.bestapp.ios")
+	plist.RunsIndependentlyOfCompanionApp(false)
+
+	assert.Nil(t, plist.Validate())
+
+	out, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>WKApplication</key><true/>")
+	assert.Contains(t, out, "<key>WKCompanionAppBundleIdentifier</key><string>com.bestapp.ios</string>")
+	assert.Contains(t, out, "<key>WKRunsIndependentlyOfCompanionApp</key><false/>")
+	assert.NotContains(t, out, "LSRequiresIPhoneOS")
+
+	plist.WatchApplication(false)
+	plist.SupportsHDR(true)
+
+	issues := plist.Report().Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "WatchApplication", issues[0].Path)
+	assert.Equal(t, CodeMissingProperty, issues[0].Code)
+	assert.Equal(t, "SupportsHDR", issues[1].Path)
+	assert.Equal(t, CodeForbiddenProperty, issues[1].Code)
+}
+
+func TestPropertyList_TVDefaults(t *testing.T) {
+	plist := New(PlatformTV)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.SupportsHDR(true)
+
+	assert.Nil(t, plist.Validate())
+
+	out, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>UIAppSupportsHDR</key><true/>")
+	assert.Contains(t, out, "<key>LSRequiresIPhoneOS</key><true/>")
+	assert.NotContains(t, out, "UIStatusBarStyle")
+
+	plist.StatusBarHidden(true)
+	plist.WatchApplication(true)
+
+	issues := plist.Report().Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "StatusBarHidden", issues[0].Path)
+	assert.Equal(t, "WatchApplication", issues[1].Path)
+}
+
+func TestPropertyList_VisionDefaults(t *testing.T) {
+	plist := New(PlatformVision)
+	plist.Defaults()
+	plist.BundleName("BestApp")
+	plist.SceneManifest(func(m *SceneManifest) {
+		m.MultipleWindows(true)
+		m.PreferredDefaultSessionRole(SceneSessionRoleImmersiveSpace)
+		m.ImmersiveSpace(func(c *SceneConfiguration) {
+			c.Name("Space")
+			c.ImmersionStyle(ImmersionStyleFull)
+		})
+	})
+
+	assert.Nil(t, plist.Validate())
+
+	out, err := plist.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>UIApplicationPreferredDefaultSceneSessionRole</key><string>UISceneSessionRoleImmersiveSpaceApplication</string>")
+	assert.Contains(t, out, "<key>UISceneSessionRoleImmersiveSpaceApplication</key>")
+	assert.Contains(t, out, "<key>UISceneInitialImmersionStyle</key><string>UIImmersionStyleFull</string>")
+
+	iOS := New(PlatformIOS)
+	iOS.Defaults()
+	iOS.BundleName("BestApp")
+	iOS.DisplayName("Best App")
+	iOS.SceneManifest(func(m *SceneManifest) {
+		m.Application(func(c *SceneConfiguration) {
+			c.Name("Default")
+		})
+		m.Volumetric(func(c *SceneConfiguration) {
+			c.Name("Volume")
+		})
+	})
+
+	issues := iOS.Report().Errors()
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "SceneManifest.Volumetric", issues[0].Path)
+	assert.Equal(t, CodeForbiddenProperty, issues[0].Code)
+}
@@ -8,8 +8,9 @@ import (
 // permissions.
 // See https://iosdevcenters.blogspot.com/2016/09/infoplist-privacy-settings-in-ios-10.html#comment-3531316086 for details.
 type Privacy struct {
-	values map[string]interface{}
-	once   sync.Once
+	values  map[string]interface{}
+	locales map[string]*Privacy
+	once    sync.Once
 }
 
 func (p *Privacy) init() {
@@ -27,10 +28,50 @@ func (p *Privacy) Set(key string, value interface{}) {
 	p.values[key] = value
 }
 
-// Apply will apply the privacy configuration to the provider property list.
-func (p *Privacy) Apply(pl *PropertyList) {
+// Localize specifies the translated privacy descriptions for a locale, i.e.
+// `fr` or `pt-BR`. Localized descriptions are written to
+// `<locale>.lproj/InfoPlist.strings` or a string catalog, see
+// `PropertyList.SaveLocalizations`. Every locale must translate every
+// description specified on the unlocalized `Privacy`.
+func (p *Privacy) Localize(locale string, f func(l *Privacy)) *Privacy {
+	if p.locales == nil {
+		p.locales = map[string]*Privacy{}
+	}
+
+	l := &Privacy{}
+	l.init()
+	f(l)
+
+	p.locales[locale] = l
+	return p
+}
+
+// first returns the first of the specified keys that has been set, or an
+// empty string.
+func (p *Privacy) first(keys ...string) string {
+	if p == nil {
+		return ""
+	}
+
+	for _, key := range keys {
+		if _, found := p.values[key]; found {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// has returns true if any of the specified keys has been set.
+func (p *Privacy) has(keys ...string) bool {
+	return p.first(keys...) != ""
+}
+
+// Apply will apply the privacy configuration to the provided property list
+// dictionary.
+func (p *Privacy) Apply(data map[string]interface{}) {
 	for key, value := range p.values {
-		pl.data[key] = value
+		data[key] = value
 	}
 }
 
@@ -278,6 +319,18 @@ func (p *Privacy) LocationUsage(desc string) *Privacy {
 	return p
 }
 
+// LocationWhenInUse specifies a message that tells the user why the app is
+// requesting access to the user’s location information while the app is in
+// use.
+//
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/NSLocationWhenInUseUsageDescription for more information.
+func (p *Privacy) LocationWhenInUse(desc string) *Privacy {
+	p.init()
+	key := privacyKeys["locationWhenInUse"]
+	p.values[key] = desc
+	return p
+}
+
 // AppleMusic specifies a message that tells the user why the app is requesting
 // access to the user’s media library.
 //
@@ -440,3 +493,14 @@ func (p *Privacy) TVProviderAccess(desc string) *Privacy {
 	p.values[key] = desc
 	return p
 }
+
+// UserTracking specifies a message that tells the user why the app is
+// requesting permission to use data for tracking the user or the device.
+//
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/NSUserTrackingUsageDescription for more information.
+func (p *Privacy) UserTracking(desc string) *Privacy {
+	p.init()
+	key := privacyKeys["userTracking"]
+	p.values[key] = desc
+	return p
+}
@@ -0,0 +1,189 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/illyabusigin/apptools/privacy"
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/illyabusigin/apptools/xcconfig"
+)
+
+// Validation codes reported when cross-checking privacy descriptions.
+const (
+	CodeEmptyUsageDescription       = "empty-usage-description"
+	CodePlaceholderUsageDescription = "placeholder-usage-description"
+	CodeUndeclaredDataType          = "undeclared-data-type"
+	CodeInconsistentTracking        = "inconsistent-tracking"
+)
+
+var (
+	// ErrEmptyUsageDescription is the warning returned for empty usage
+	// descriptions, which are rejected during App Review.
+	ErrEmptyUsageDescription = errors.New("Usage description is empty")
+
+	// ErrPlaceholderUsageDescription is the warning returned for usage
+	// descriptions that look like placeholders, which are rejected during App
+	// Review.
+	ErrPlaceholderUsageDescription = errors.New("Usage description looks like a placeholder")
+
+	// ErrUndeclaredDataType is the warning returned for usage descriptions
+	// requesting access to data the privacy manifest does not declare as
+	// collected.
+	ErrUndeclaredDataType = errors.New("Usage description requests data the privacy manifest does not declare")
+
+	// ErrTrackingUndeclared is the warning returned when the app asks for
+	// tracking permission while the privacy manifest does not declare
+	// tracking.
+	ErrTrackingUndeclared = errors.New("Tracking usage description is specified but the privacy manifest does not declare tracking")
+
+	placeholderRegex = regexp.MustCompile(`(?i)^(todo|tbd|fixme|placeholder|lorem ipsum)\b|^(test(ing)?|description|usage description|n/?a|none|x+|\.+|\?+|-+)$`)
+)
+
+// capabilityDescription maps a device capability to the usage descriptions it
+// requires. Any one of the usage descriptions satisfies the capability.
+type capabilityDescription struct {
+	name    string
+	enabled func(c *DeviceCapabilities) bool
+	keys    []string
+}
+
+var capabilityDescriptions = []capabilityDescription{
+	{"microphone", func(c *DeviceCapabilities) bool { return c.microphone }, []string{privacyKeys["microphone"]}},
+	{"healthkit", func(c *DeviceCapabilities) bool { return c.healthKit },
+		[]string{privacyKeys["healthRecordsShareUsage"], privacyKeys["healthRecordUpdateUsage"]}},
+	{"nfc", func(c *DeviceCapabilities) bool { return c.nfc }, []string{privacyKeys["nfcScan"]}},
+	{"camera", func(c *DeviceCapabilities) bool {
+		return c.stillCamera || c.videoCamera || c.autoFocusCamera || c.frontFacingCamera || c.cameraFlash || c.arKit
+	}, []string{privacyKeys["camera"]}},
+	{"location", func(c *DeviceCapabilities) bool { return c.gps || c.locationServices },
+		[]string{privacyKeys["locationWhenInUse"], privacyKeys["locationEverything"], privacyKeys["locationAlways"]}},
+	{"bluetooth-le", func(c *DeviceCapabilities) bool { return c.bluetoothLE },
+		[]string{privacyKeys["bluetoothAlways"], privacyKeys["bluetoothPeripheral"]}},
+}
+
+// privacyReport cross-checks the required device capabilities against the
+// privacy usage descriptions and flags descriptions App Review rejects.
+func (p *PropertyList) privacyReport() *validation.Report {
+	r := &validation.Report{}
+
+	if c := p.capabilities; c != nil {
+		for _, d := range capabilityDescriptions {
+			if d.enabled(c) && !p.privacy.has(d.keys...) {
+				r.AddWarning("Privacy", CodeMissingUsageDescription, d.keys[0],
+					fmt.Errorf("%w for the %v device capability", ErrMissingUsageDescription, d.name))
+			}
+		}
+	}
+
+	if privacy := p.privacy; privacy != nil {
+		descriptionsReport(r, "Privacy", privacy.values, p.buildSettings)
+
+		for _, locale := range sortedPrivacyLocales(privacy.locales) {
+			descriptionsReport(r, fmt.Sprintf("Localizations[%v]", locale), privacy.locales[locale].values, p.buildSettings)
+		}
+	}
+
+	return r
+}
+
+// descriptionsReport reports empty and placeholder-like usage descriptions.
+// Build setting references are expanded first when build settings are
+// specified.
+func descriptionsReport(r *validation.Report, path string, values map[string]interface{}, settings xcconfig.Settings) {
+	for _, key := range sortedKeys(values) {
+		description, ok := values[key].(string)
+		if !ok {
+			continue
+		}
+
+		if settings != nil {
+			description, _ = settings.Expand(description)
+		}
+
+		description = strings.TrimSpace(description)
+
+		switch {
+		case description == "":
+			r.AddWarning(path, CodeEmptyUsageDescription, key, ErrEmptyUsageDescription)
+		case placeholderRegex.MatchString(description) || description == key:
+			r.AddWarning(path, CodePlaceholderUsageDescription, key,
+				fmt.Errorf("%w: %q", ErrPlaceholderUsageDescription, description))
+		}
+	}
+}
+
+// descriptionDataType maps usage descriptions to the privacy manifest data
+// types the data they grant access to is collected as. Any one of the data
+// types satisfies the usage descriptions.
+type descriptionDataType struct {
+	name      string
+	keys      []string
+	dataTypes []privacy.DataType
+}
+
+var descriptionDataTypes = []descriptionDataType{
+	{"location", []string{privacyKeys["locationWhenInUse"], privacyKeys["locationEverything"], privacyKeys["locationAlways"], privacyKeys["locationUsage"]},
+		[]privacy.DataType{privacy.DataPreciseLocation, privacy.DataCoarseLocation}},
+	{"contacts", []string{privacyKeys["contacts"]}, []privacy.DataType{privacy.DataContacts}},
+	{"health", []string{privacyKeys["healthRecordsShareUsage"], privacyKeys["healthRecordUpdateUsage"], privacyKeys["healthRecordsUsage"]},
+		[]privacy.DataType{privacy.DataHealth, privacy.DataFitness}},
+}
+
+// ManifestReport cross-checks the privacy usage descriptions against a
+// privacy manifest. Usage descriptions for location, contacts and health data
+// should be matched by a collected data type, and
+// `NSUserTrackingUsageDescription` should be matched by the manifest
+// declaring tracking, and vice versa. Every issue is reported as a warning.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files for more information.
+func (p *PropertyList) ManifestReport(m *privacy.Manifest) *validation.Report {
+	r := &validation.Report{}
+
+	declared := map[privacy.DataType]bool{}
+	for _, dataType := range m.CollectedDataTypes() {
+		declared[dataType] = true
+	}
+
+	for _, d := range descriptionDataTypes {
+		key := p.privacy.first(d.keys...)
+		if key == "" {
+			continue
+		}
+
+		found := false
+		for _, dataType := range d.dataTypes {
+			found = found || declared[dataType]
+		}
+
+		if !found {
+			r.AddWarning("Privacy", CodeUndeclaredDataType, key,
+				fmt.Errorf("%w: %v data is not declared as collected", ErrUndeclaredDataType, d.name))
+		}
+	}
+
+	trackingKey := privacyKeys["userTracking"]
+
+	switch hasDescription := p.privacy.has(trackingKey); {
+	case hasDescription && !m.IsTracking():
+		r.AddWarning("Privacy", CodeInconsistentTracking, trackingKey, ErrTrackingUndeclared)
+	case !hasDescription && m.IsTracking():
+		r.AddWarning("Privacy", CodeMissingUsageDescription, trackingKey,
+			fmt.Errorf("%w for tracking declared by the privacy manifest", ErrMissingUsageDescription))
+	}
+
+	return r
+}
+
+func sortedPrivacyLocales(m map[string]*Privacy) []string {
+	locales := make([]string, 0, len(m))
+	for locale := range m {
+		locales = append(locales, locale)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
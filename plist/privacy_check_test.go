@@ -0,0 +1,114 @@
+package plist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/illyabusigin/apptools/privacy"
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/illyabusigin/apptools/xcconfig"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_PrivacyReport(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Capabilities(func(c *DeviceCapabilities) {
+		c.Microphone().HealthKit().NFC().StillCamera().GPS().ARMv7()
+	})
+	plist.Privacy(func(p *Privacy) {
+		p.Camera("TODO")
+		p.LocationWhenInUse("  ")
+		p.Contacts("NSContactsUsageDescription")
+		p.Calendar("We add your bookings to your calendar")
+		p.Set("NSCustomUsageDescription", true)
+		p.Localize("fr", func(l *Privacy) {
+			l.Camera("Lorem ipsum dolor sit amet")
+		})
+	})
+
+	report := plist.privacyReport()
+	assert.Nil(t, report.Err(), "Privacy cross-checks should only return warnings")
+
+	warnings := []string{}
+	for _, issue := range report.Warnings() {
+		warnings = append(warnings, fmt.Sprintf("%v %v %v", issue.Path, issue.Code, issue.Key))
+	}
+
+	assert.Equal(t, []string{
+		"Privacy missing-usage-description NSMicrophoneUsageDescription",
+		"Privacy missing-usage-description NSHealthShareUsageDescription",
+		"Privacy missing-usage-description NFCReaderUsageDescription",
+		"Privacy placeholder-usage-description NSCameraUsageDescription",
+		"Privacy placeholder-usage-description NSContactsUsageDescription",
+		"Privacy empty-usage-description NSLocationWhenInUseUsageDescription",
+		"Localizations[fr] placeholder-usage-description NSCameraUsageDescription",
+	}, warnings)
+}
+
+func TestPlaceholderRegex(t *testing.T) {
+	placeholders := []string{"TODO", "todo: write this", "TBD", "Placeholder", "test", "N/A", "xxx", "..."}
+	for _, p := range placeholders {
+		assert.True(t, placeholderRegex.MatchString(p), p)
+	}
+
+	descriptions := []string{"Testimonials are shared with your friends", "We use the camera to scan receipts", "None of your data leaves the device", "$(CAMERA_USAGE_DESCRIPTION)"}
+	for _, d := range descriptions {
+		assert.False(t, placeholderRegex.MatchString(d), d)
+	}
+}
+
+func TestPropertyList_PrivacyReportBuildSettings(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Privacy(func(p *Privacy) {
+		p.Camera("$(CAMERA_USAGE_DESCRIPTION)")
+		p.Microphone("$(MICROPHONE_USAGE_DESCRIPTION)")
+	})
+
+	assert.True(t, plist.privacyReport().Empty(), "Build setting references should not be reported as placeholders")
+
+	plist.BuildSettings(xcconfig.Settings{
+		"CAMERA_USAGE_DESCRIPTION":     "We use the camera to scan receipts",
+		"MICROPHONE_USAGE_DESCRIPTION": "TODO",
+	})
+
+	warnings := plist.privacyReport().Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodePlaceholderUsageDescription, warnings[0].Code)
+	assert.Equal(t, "NSMicrophoneUsageDescription", warnings[0].Key)
+}
+
+func TestPropertyList_ManifestReport(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Privacy(func(p *Privacy) {
+		p.LocationAlways("We track your runs in the background")
+		p.Contacts("We find your friends")
+		p.UserTracking("We show you relevant ads")
+	})
+
+	manifest := privacy.New()
+	manifest.CollectedData(privacy.DataCoarseLocation, func(d *privacy.CollectedData) {
+		d.Purposes(privacy.PurposeAppFunctionality)
+	})
+
+	warnings := []string{}
+	for _, issue := range plist.ManifestReport(manifest).Issues {
+		assert.Equal(t, validation.SeverityWarning, issue.Severity)
+		warnings = append(warnings, fmt.Sprintf("%v %v %v", issue.Path, issue.Code, issue.Key))
+	}
+
+	assert.Equal(t, []string{
+		"Privacy undeclared-data-type NSContactsUsageDescription",
+		"Privacy inconsistent-tracking NSUserTrackingUsageDescription",
+	}, warnings)
+
+	manifest.Tracking(true).TrackingDomains("ads.best.app")
+	manifest.CollectedData(privacy.DataContacts, func(d *privacy.CollectedData) {
+		d.Purposes(privacy.PurposeAppFunctionality)
+	})
+	assert.True(t, plist.ManifestReport(manifest).Empty())
+
+	issues := New(PlatformIOS).ManifestReport(manifest).Warnings()
+	assert.Len(t, issues, 1)
+	assert.Equal(t, CodeMissingUsageDescription, issues[0].Code)
+	assert.Equal(t, "NSUserTrackingUsageDescription", issues[0].Key)
+}
@@ -0,0 +1,175 @@
+package plist
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"howett.net/plist"
+)
+
+// Values is a resolved property list dictionary. It contains typed accessors
+// for reading back common properties.
+type Values map[string]interface{}
+
+// Resolve computes the property list dictionary, including any custom keys
+// specified with `Set`, without validating or encoding it. The returned
+// dictionary is a copy and can be modified freely.
+func (p *PropertyList) Resolve() Values {
+	p.init()
+
+	return Values(copyValue(p.build()).(map[string]interface{}))
+}
+
+// Decode decodes a property list in any format, i.e. a previously shipped
+// `Info.plist`, into its values.
+func Decode(data []byte) (Values, error) {
+	values := Values{}
+
+	if err := plist.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Load reads and decodes the property list at the specified path.
+func Load(path string) (Values, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decode(data)
+}
+
+// copyValue returns a deep copy of the dictionaries and arrays contained in a
+// property list value.
+func copyValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(value))
+		for key, val := range value {
+			c[key] = copyValue(val)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(value))
+		for idx, val := range value {
+			c[idx] = copyValue(val)
+		}
+		return c
+	case []map[string]interface{}:
+		c := make([]map[string]interface{}, len(value))
+		for idx, val := range value {
+			c[idx] = copyValue(val).(map[string]interface{})
+		}
+		return c
+	case []string:
+		return append([]string{}, value...)
+	default:
+		return v
+	}
+}
+
+// Get returns the value of the specified key and whether it was found.
+func (v Values) Get(key string) (interface{}, bool) {
+	value, found := v[key]
+	return value, found
+}
+
+// String returns the value of the specified key if it is a string.
+func (v Values) String(key string) (string, bool) {
+	value, ok := v[key].(string)
+	return value, ok
+}
+
+// Bool returns the value of the specified key if it is a boolean.
+func (v Values) Bool(key string) (bool, bool) {
+	value, ok := v[key].(bool)
+	return value, ok
+}
+
+// Strings returns the value of the specified key if it is an array of
+// strings.
+func (v Values) Strings(key string) ([]string, bool) {
+	switch value := v[key].(type) {
+	case []string:
+		return value, true
+	case []interface{}:
+		values := make([]string, len(value))
+		for idx, val := range value {
+			s, ok := val.(string)
+			if !ok {
+				return nil, false
+			}
+			values[idx] = s
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+func (v Values) stringValue(key string) string {
+	value, _ := v.String(key)
+	return value
+}
+
+// BundleID returns the `CFBundleIdentifier`.
+func (v Values) BundleID() string {
+	return v.stringValue(keyCFBundleIdentifier)
+}
+
+// BundleName returns the `CFBundleName`.
+func (v Values) BundleName() string {
+	return v.stringValue(keyCFBundleName)
+}
+
+// DisplayName returns the `CFBundleDisplayName`.
+func (v Values) DisplayName() string {
+	return v.stringValue(keyCFBundleDisplayName)
+}
+
+// VersionShort returns the `CFBundleShortVersionString`.
+func (v Values) VersionShort() string {
+	return v.stringValue(keyCFBundleShortVersionString)
+}
+
+// Version returns the `CFBundleVersion`.
+func (v Values) Version() string {
+	return v.stringValue(keyCFBundleVersion)
+}
+
+// Orientations returns the `UISupportedInterfaceOrientations`.
+func (v Values) Orientations() []string {
+	orientations, _ := v.Strings(keyUISupportedInterfaceOrientations)
+	return orientations
+}
+
+// TabletOrientations returns the `UISupportedInterfaceOrientations~ipad`.
+func (v Values) TabletOrientations() []string {
+	orientations, _ := v.Strings(keyUISupportedInterfaceOrientationsIPad)
+	return orientations
+}
+
+// PrivacyKeys returns the sorted privacy usage description keys, i.e.
+// `NSCameraUsageDescription`.
+func (v Values) PrivacyKeys() []string {
+	known := map[string]bool{}
+	for _, key := range privacyKeys {
+		known[key] = true
+	}
+
+	keys := []string{}
+	for key := range v {
+		if known[key] || strings.HasSuffix(key, "UsageDescription") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
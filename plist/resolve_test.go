@@ -0,0 +1,50 @@
+package plist
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_Resolve(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.Defaults()
+	plist.BundleID("com.best.app")
+	plist.DisplayName("Best App")
+	plist.VersionShort("1.2.0")
+	plist.TabletOrientations(func(o *Orientations) {
+		o.Portrait()
+		o.UpsideDown()
+	})
+	plist.Privacy(func(p *Privacy) {
+		p.Camera("We use the camera to scan receipts")
+		p.Set("NSUserTrackingUsageDescription", "We use your data to show relevant ads")
+	})
+	plist.Set("CFBundleVersion", "42")
+	plist.Set("BestAppBuildDate", "2020-12-01")
+
+	values := plist.Resolve()
+	assert.Equal(t, "com.best.app", values.BundleID())
+	assert.Equal(t, "Best App", values.DisplayName())
+	assert.Equal(t, "1.2.0", values.VersionShort())
+	assert.Equal(t, "42", values.Version(), "Custom keys should override builder keys")
+	assert.Equal(t, "", values.BundleName())
+	assert.Equal(t, []string{"UIInterfaceOrientationPortrait"}, values.Orientations())
+	assert.Equal(t, []string{"UIInterfaceOrientationPortrait", "UIInterfaceOrientationUpsideDown"}, values.TabletOrientations())
+	assert.Equal(t, []string{"NSCameraUsageDescription", "NSUserTrackingUsageDescription"}, values.PrivacyKeys())
+
+	date, ok := values.String("BestAppBuildDate")
+	assert.True(t, ok)
+	assert.Equal(t, "2020-12-01", date)
+
+	requiresIOS, ok := values.Bool("LSRequiresIPhoneOS")
+	assert.True(t, ok)
+	assert.True(t, requiresIOS)
+
+	_, ok = values.Strings("CFBundleVersion")
+	assert.False(t, ok)
+
+	values.Orientations()[0] = "UIInterfaceOrientationLandscapeLeft"
+	assert.Equal(t, []string{"UIInterfaceOrientationPortrait"}, plist.Resolve().Orientations(),
+		"Modifying resolved values should not modify the builder")
+}
@@ -1,34 +1,95 @@
 package plist
 
 import (
-	"fmt"
+	"github.com/illyabusigin/apptools/validation"
 )
 
 // SceneManifest contains configuration information about the app's scene-based
 // life-cycle support.
 type SceneManifest struct {
 	multipleWindows bool
+	preferredRole   SceneSessionRole
 	application     *SceneConfiguration
 	externalDisplay *SceneConfiguration
+	volumetric      *SceneConfiguration
+	immersiveSpace  *SceneConfiguration
 }
 
+// SceneSessionRole identifies the role of a scene session.
+// See https://developer.apple.com/documentation/uikit/uiscenesession/role for more information.
+type SceneSessionRole string
+
+const (
+	// SceneSessionRoleApplication is the role for the app's main windows.
+	SceneSessionRoleApplication SceneSessionRole = "UIWindowSceneSessionRoleApplication"
+
+	// SceneSessionRoleExternalDisplay is the role for scenes shown on an
+	// external display.
+	SceneSessionRoleExternalDisplay SceneSessionRole = "UIWindowSceneSessionRoleExternalDisplay"
+
+	// SceneSessionRoleVolumetric is the role for visionOS volumetric windows.
+	SceneSessionRoleVolumetric SceneSessionRole = "UIWindowSceneSessionRoleVolumetricApplication"
+
+	// SceneSessionRoleImmersiveSpace is the role for visionOS immersive spaces.
+	SceneSessionRoleImmersiveSpace SceneSessionRole = "UISceneSessionRoleImmersiveSpaceApplication"
+)
+
+// ImmersionStyle specifies the initial immersion style of a visionOS immersive
+// space.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uisceneconfigurations/uisceneinitialimmersionstyle for more information.
+type ImmersionStyle string
+
+const (
+	// ImmersionStyleMixed blends the immersive space with the passthrough.
+	ImmersionStyleMixed ImmersionStyle = "UIImmersionStyleMixed"
+
+	// ImmersionStyleProgressive partially replaces the passthrough.
+	ImmersionStyleProgressive ImmersionStyle = "UIImmersionStyleProgressive"
+
+	// ImmersionStyleFull completely replaces the passthrough.
+	ImmersionStyleFull ImmersionStyle = "UIImmersionStyleFull"
+)
+
 // Validate will validate the SceneManifest configuration and return any
 // errors found.
 func (m *SceneManifest) Validate() error {
+	return m.Report().Err()
+}
+
+// Report will validate the SceneManifest configuration and return a report
+// containing every issue found.
+func (m *SceneManifest) Report() *validation.Report {
+	r := &validation.Report{}
+
+	// Apps specifying a preferred default role, i.e. SwiftUI apps on
+	// visionOS, are not required to declare an application configuration.
 	if m.application == nil {
-		return errMissingProperty(fmt.Sprintf("SceneManifest.Application (UISceneConfigurations)"))
+		if m.preferredRole == "" {
+			r.AddError("Application", CodeMissingProperty, "UISceneConfigurations", ErrMissingRequiredProperty)
+		}
+	} else {
+		r.Merge("Application", m.application.Report())
+	}
+
+	if m.externalDisplay != nil {
+		r.Merge("ExternalDisplay", m.externalDisplay.Report())
+	}
+
+	if m.volumetric != nil {
+		r.Merge("Volumetric", m.volumetric.Report())
 	}
 
-	if err := m.application.Validate(); err != nil {
-		return err
+	if m.immersiveSpace != nil {
+		r.Merge("ImmersiveSpace", m.immersiveSpace.Report())
 	}
 
-	return nil
+	return r
 }
 
-// Apply will apply the scene manifest to the specified PropertyList.
-func (m *SceneManifest) Apply(p *PropertyList) {
-	p.data["UIApplicationSceneManifest"] = m.build()
+// Apply will apply the scene manifest to the specified property list
+// dictionary.
+func (m *SceneManifest) Apply(data map[string]interface{}) {
+	data[keyUIApplicationSceneManifest] = m.build()
 }
 
 func (m *SceneManifest) build() map[string]interface{} {
@@ -36,16 +97,28 @@ func (m *SceneManifest) build() map[string]interface{} {
 		"UIApplicationSupportsMultipleScenes": m.multipleWindows,
 	}
 
+	if m.preferredRole != "" {
+		data[keyUIApplicationPreferredDefaultSceneSessionRole] = string(m.preferredRole)
+	}
+
 	configurations := map[string]interface{}{}
 
 	if m.application != nil {
 		application := m.application.build()
-		configurations["UIWindowSceneSessionRoleApplication"] = application
+		configurations[string(SceneSessionRoleApplication)] = application
 	}
 
 	if m.externalDisplay != nil {
 		externalDisplay := m.externalDisplay.build()
-		configurations["UIWindowSceneSessionRoleExternalDisplay"] = externalDisplay
+		configurations[string(SceneSessionRoleExternalDisplay)] = externalDisplay
+	}
+
+	if m.volumetric != nil {
+		configurations[string(SceneSessionRoleVolumetric)] = m.volumetric.build()
+	}
+
+	if m.immersiveSpace != nil {
+		configurations[string(SceneSessionRoleImmersiveSpace)] = m.immersiveSpace.build()
 	}
 
 	data["UISceneConfigurations"] = configurations
@@ -69,6 +142,29 @@ func (m *SceneManifest) ExternalDisplay(f func(c *SceneConfiguration)) {
 	f(m.externalDisplay)
 }
 
+// Volumetric specifies the scenes that you use to display content in a
+// visionOS volumetric window.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uisceneconfigurations for more information.
+func (m *SceneManifest) Volumetric(f func(c *SceneConfiguration)) {
+	m.volumetric = &SceneConfiguration{}
+	f(m.volumetric)
+}
+
+// ImmersiveSpace specifies the scenes that you use to display content in a
+// visionOS immersive space.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uisceneconfigurations for more information.
+func (m *SceneManifest) ImmersiveSpace(f func(c *SceneConfiguration)) {
+	m.immersiveSpace = &SceneConfiguration{}
+	f(m.immersiveSpace)
+}
+
+// PreferredDefaultSessionRole specifies the role of the scene the app opens
+// at launch on visionOS, i.e. `SceneSessionRoleImmersiveSpace`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uiapplicationpreferreddefaultscenesessionrole for more information.
+func (m *SceneManifest) PreferredDefaultSessionRole(role SceneSessionRole) {
+	m.preferredRole = role
+}
+
 // MultipleWindows specifies a boolean value indicating whether the app
 // supports two or more scenes simultaneously.
 // See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uiapplicationsupportsmultiplescenes for more information.
@@ -85,15 +181,24 @@ type SceneConfiguration struct {
 	className         *string
 	delegateClassName *string
 	storyboardName    *string
+	immersionStyle    ImmersionStyle
 }
 
 // Validate will validate the SceneConfiguration, returning any errors.
 func (c *SceneConfiguration) Validate() error {
+	return c.Report().Err()
+}
+
+// Report will validate the SceneConfiguration and return a report containing
+// every issue found.
+func (c *SceneConfiguration) Report() *validation.Report {
+	r := &validation.Report{}
+
 	if c.name == "" {
-		return errMissingProperty(fmt.Sprintf("SceneConfiguration.Name (UISceneConfigurationName)"))
+		r.AddError("Name", CodeMissingProperty, "UISceneConfigurationName", ErrMissingRequiredProperty)
 	}
 
-	return nil
+	return r
 }
 
 func (c *SceneConfiguration) build() map[string]interface{} {
@@ -113,6 +218,10 @@ func (c *SceneConfiguration) build() map[string]interface{} {
 		data["UISceneStoryboardFile"] = storyboardName
 	}
 
+	if c.immersionStyle != "" {
+		data[keyUISceneInitialImmersionStyle] = string(c.immersionStyle)
+	}
+
 	return data
 }
 
@@ -142,6 +251,13 @@ func (c *SceneConfiguration) Storyboard(v string) {
 	c.storyboardName = &v
 }
 
+// ImmersionStyle specifies the initial immersion style of a visionOS
+// immersive space scene.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiapplicationscenemanifest/uisceneconfigurations/uisceneinitialimmersionstyle for more information.
+func (c *SceneConfiguration) ImmersionStyle(v ImmersionStyle) {
+	c.immersionStyle = v
+}
+
 func _sceneManifest() {
 	s := &SceneManifest{}
 	s.MultipleWindows(false)
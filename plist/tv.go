@@ -0,0 +1,17 @@
+package plist
+
+// SupportsHDR specifies a boolean value indicating whether the tvOS app
+// supports HDR content.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/uiappsupportshdr for more information.
+func (p *PropertyList) SupportsHDR(v bool) *PropertyList {
+	p.touch(keyUIAppSupportsHDR)
+	p.supportsHDR = v
+	return p
+}
+
+// applyTV applies any tvOS specific properties that have been specified.
+func (p *PropertyList) applyTV(data map[string]interface{}) {
+	if p.supportsHDR {
+		data[keyUIAppSupportsHDR] = true
+	}
+}
@@ -0,0 +1,177 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for URL types.
+const (
+	CodeInvalidURLScheme   = "invalid-url-scheme"
+	CodeDuplicateURLScheme = "duplicate-url-scheme"
+)
+
+var (
+	// ErrInvalidURLScheme is the error returned for URL schemes that are not
+	// valid RFC 3986 schemes.
+	ErrInvalidURLScheme = errors.New("URL schemes must start with a letter followed by letters, digits, '+', '-' or '.'")
+
+	// ErrDuplicateURLScheme is the error returned for URL schemes declared
+	// more than once.
+	ErrDuplicateURLScheme = errors.New("URL scheme is declared more than once")
+
+	urlSchemeRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*$`)
+)
+
+// BundleTypeRole describes the app's role with respect to a URL or document
+// type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundledocumenttypes/cfbundletyperole for more information.
+type BundleTypeRole string
+
+const (
+	// RoleEditor specifies the app can read and modify the type.
+	RoleEditor BundleTypeRole = "Editor"
+
+	// RoleViewer specifies the app can read the type.
+	RoleViewer BundleTypeRole = "Viewer"
+
+	// RoleShell specifies the app provides runtime services for the type.
+	RoleShell BundleTypeRole = "Shell"
+
+	// RoleNone specifies the app declares information about the type only.
+	RoleNone BundleTypeRole = "None"
+)
+
+// URLType describes a URL scheme the app handles.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes for more information.
+type URLType struct {
+	name     string
+	schemes  []string
+	role     BundleTypeRole
+	iconFile string
+}
+
+// Name specifies the abstract name for the URL type, typically a reverse DNS
+// identifier, i.e. `com.best.app`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes/cfbundleurlname for more information.
+func (u *URLType) Name(v string) *URLType {
+	u.name = v
+	return u
+}
+
+// Schemes specifies the URL schemes handled by the app, i.e. `bestapp`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes/cfbundleurlschemes for more information.
+func (u *URLType) Schemes(schemes ...string) *URLType {
+	u.schemes = append(u.schemes, schemes...)
+	return u
+}
+
+// Role specifies the app's role with respect to the URL type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes/cfbundletyperole for more information.
+func (u *URLType) Role(v BundleTypeRole) *URLType {
+	u.role = v
+	return u
+}
+
+// IconFile specifies the name of the icon file used for the URL type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes/cfbundleurliconfile for more information.
+func (u *URLType) IconFile(v string) *URLType {
+	u.iconFile = v
+	return u
+}
+
+// Validate will validate the URL type and return any errors found.
+func (u *URLType) Validate() error {
+	return u.Report().Err()
+}
+
+// Report will validate the URL type and return a report containing every
+// issue found. Duplicate schemes across URL types are reported by the
+// property list.
+func (u *URLType) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if len(u.schemes) == 0 {
+		r.AddError("Schemes", CodeMissingProperty, keyCFBundleURLSchemes, ErrMissingRequiredProperty)
+	}
+
+	for idx, scheme := range u.schemes {
+		if !urlSchemeRegex.MatchString(scheme) {
+			r.AddError(validation.Index("Schemes", idx), CodeInvalidURLScheme, keyCFBundleURLSchemes,
+				fmt.Errorf("%w: %q", ErrInvalidURLScheme, scheme))
+		}
+	}
+
+	return r
+}
+
+func (u *URLType) build() map[string]interface{} {
+	data := map[string]interface{}{
+		keyCFBundleURLSchemes: u.schemes,
+	}
+
+	if u.name != "" {
+		data[keyCFBundleURLName] = u.name
+	}
+
+	if u.role != "" {
+		data[keyCFBundleTypeRole] = string(u.role)
+	}
+
+	if u.iconFile != "" {
+		data[keyCFBundleURLIconFile] = u.iconFile
+	}
+
+	return data
+}
+
+// urlTypesReport validates every URL type and reports schemes declared more
+// than once. URL schemes are case-insensitive.
+func urlTypesReport(types []*URLType) *validation.Report {
+	r := &validation.Report{}
+	seen := map[string]bool{}
+
+	for idx, u := range types {
+		path := validation.Index("URLTypes", idx)
+		r.Merge(path, u.Report())
+
+		for sIdx, scheme := range u.schemes {
+			normalized := strings.ToLower(scheme)
+			if seen[normalized] {
+				r.AddError(validation.Join(path, validation.Index("Schemes", sIdx)), CodeDuplicateURLScheme,
+					keyCFBundleURLSchemes, fmt.Errorf("%w: %q", ErrDuplicateURLScheme, scheme))
+			}
+
+			seen[normalized] = true
+		}
+	}
+
+	return r
+}
+
+// URLType declares a URL scheme handled by the app. Call it once per URL type.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/cfbundleurltypes for more information.
+func (p *PropertyList) URLType(f func(u *URLType)) *PropertyList {
+	p.touch(keyCFBundleURLTypes)
+	u := &URLType{}
+	f(u)
+	p.urlTypes = append(p.urlTypes, u)
+	return p
+}
+
+func (p *PropertyList) applyURLTypes(data map[string]interface{}) {
+	if len(p.urlTypes) == 0 {
+		return
+	}
+
+	urlTypes := make([]map[string]interface{}, len(p.urlTypes))
+	for idx, u := range p.urlTypes {
+		urlTypes[idx] = u.build()
+	}
+
+	data[keyCFBundleURLTypes] = urlTypes
+}
@@ -0,0 +1,51 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestPropertyList_URLTypes(t *testing.T) {
+	plist := New(PlatformIOS)
+	plist.URLType(func(u *URLType) {
+		u.Name("com.best.app").Schemes("bestapp", "best-app+v2").Role(RoleEditor)
+	})
+	plist.URLType(func(u *URLType) {
+		u.Schemes("BestApp", "2best", "best_app")
+	})
+	plist.URLType(func(u *URLType) {})
+
+	report := plist.Report()
+
+	issues := []string{}
+	for _, issue := range report.Errors() {
+		if issue.Key == keyCFBundleURLSchemes {
+			issues = append(issues, issue.Path+" "+issue.Code)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"URLTypes[1].Schemes[1] invalid-url-scheme",
+		"URLTypes[1].Schemes[2] invalid-url-scheme",
+		"URLTypes[1].Schemes[0] duplicate-url-scheme",
+		"URLTypes[2].Schemes missing-property",
+	}, issues)
+	assert.True(t, errors.Is(report, ErrDuplicateURLScheme))
+
+	data := plist.build()
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"CFBundleURLName":    "com.best.app",
+			"CFBundleURLSchemes": []string{"bestapp", "best-app+v2"},
+			"CFBundleTypeRole":   "Editor",
+		},
+		{
+			"CFBundleURLSchemes": []string{"BestApp", "2best", "best_app"},
+		},
+		{
+			"CFBundleURLSchemes": []string(nil),
+		},
+	}, data["CFBundleURLTypes"])
+}
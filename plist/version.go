@@ -0,0 +1,252 @@
+package plist
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported for bundle versions.
+const (
+	CodeInvalidVersion      = "invalid-version"
+	CodeVersionNotIncreased = "version-not-increased"
+)
+
+var (
+	// ErrInvalidVersion is the error returned for versions that are not one
+	// to three period-separated integers, i.e. `1.2.3`.
+	ErrInvalidVersion = errors.New("Versions must be one to three period-separated integers")
+
+	// ErrVersionNotIncreased is the error returned when the version is lower
+	// than a previously shipped version, or when the build number was not
+	// increased for the same version.
+	ErrVersionNotIncreased = errors.New("Version must be greater than the previously shipped version")
+
+	versionRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+)
+
+// Version is a bundle version made of one to three period-separated
+// integers, i.e. `1.2.3`.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/CFBundleShortVersionString for more information.
+type Version []int
+
+// ParseVersion parses a bundle version, i.e. `1.2.3`.
+func ParseVersion(v string) (Version, error) {
+	if !versionRegex.MatchString(v) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVersion, v)
+	}
+
+	components := strings.Split(v, ".")
+
+	version := make(Version, len(components))
+	for idx, c := range components {
+		n, err := strconv.Atoi(c)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidVersion, v)
+		}
+		version[idx] = n
+	}
+
+	return version, nil
+}
+
+// String returns the version as period-separated integers.
+func (v Version) String() string {
+	components := make([]string, len(v))
+	for idx, n := range v {
+		components[idx] = strconv.Itoa(n)
+	}
+
+	return strings.Join(components, ".")
+}
+
+// Compare returns -1, 0 or 1 if the version is lower than, equal to or
+// greater than the other version. Missing components are treated as zero,
+// i.e. `1.2` is equal to `1.2.0`.
+func (v Version) Compare(other Version) int {
+	for idx := 0; idx < len(v) || idx < len(other); idx++ {
+		a, b := v.component(idx), other.component(idx)
+
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (v Version) component(idx int) int {
+	if idx < len(v) {
+		return v[idx]
+	}
+
+	return 0
+}
+
+// bump increments the component at the specified index and resets the
+// following components to zero, adding components as needed.
+func (v Version) bump(idx int) Version {
+	size := len(v)
+	if size < idx+1 {
+		size = idx + 1
+	}
+
+	bumped := make(Version, size)
+	for i := 0; i < idx; i++ {
+		bumped[i] = v.component(i)
+	}
+	bumped[idx] = v.component(idx) + 1
+
+	return bumped
+}
+
+// BumpMajor returns the next major version, i.e. `1.2.3` becomes `2.0.0`.
+func (v Version) BumpMajor() Version {
+	return v.bump(0)
+}
+
+// BumpMinor returns the next minor version, i.e. `1.2.3` becomes `1.3.0`.
+func (v Version) BumpMinor() Version {
+	return v.bump(1)
+}
+
+// BumpPatch returns the next patch version, i.e. `1.2.3` becomes `1.2.4`.
+func (v Version) BumpPatch() Version {
+	return v.bump(2)
+}
+
+// BumpLast increments the last component of the version, i.e. `41` becomes
+// `42` and `1.2.9` becomes `1.2.10`.
+func (v Version) BumpLast() Version {
+	if len(v) == 0 {
+		return Version{1}
+	}
+
+	bumped := append(Version{}, v...)
+	bumped[len(bumped)-1]++
+
+	return bumped
+}
+
+// bumpVersionShort replaces `CFBundleShortVersionString` with the result of
+// bump.
+func (p *PropertyList) bumpVersionShort(bump func(v Version) Version) error {
+	v, err := ParseVersion(p.applicationVersionShort)
+	if err != nil {
+		return err
+	}
+
+	p.applicationVersionShort = bump(v).String()
+
+	return nil
+}
+
+// BumpMajor increments the major component of `CFBundleShortVersionString`,
+// i.e. `1.2.3` becomes `2.0.0`. An error is returned if the version is not a
+// valid version, such as a build setting reference.
+func (p *PropertyList) BumpMajor() error {
+	return p.bumpVersionShort(Version.BumpMajor)
+}
+
+// BumpMinor increments the minor component of `CFBundleShortVersionString`,
+// i.e. `1.2.3` becomes `1.3.0`. An error is returned if the version is not a
+// valid version, such as a build setting reference.
+func (p *PropertyList) BumpMinor() error {
+	return p.bumpVersionShort(Version.BumpMinor)
+}
+
+// BumpPatch increments the patch component of `CFBundleShortVersionString`,
+// i.e. `1.2.3` becomes `1.2.4`. An error is returned if the version is not a
+// valid version, such as a build setting reference.
+func (p *PropertyList) BumpPatch() error {
+	return p.bumpVersionShort(Version.BumpPatch)
+}
+
+// BumpBuild increments the last component of `CFBundleVersion`, i.e. `41`
+// becomes `42`. An error is returned if the build version is not a valid
+// version, such as a build setting reference.
+func (p *PropertyList) BumpBuild() error {
+	v, err := ParseVersion(p.bundleVersion)
+	if err != nil {
+		return err
+	}
+
+	p.bundleVersion = v.BumpLast().String()
+
+	return nil
+}
+
+// versionReport validates the format of the versions that are not build
+// setting references.
+func versionReport(data map[string]interface{}) *validation.Report {
+	r := &validation.Report{}
+
+	for _, property := range []struct{ path, key string }{
+		{"VersionShort", keyCFBundleShortVersionString},
+		{"Version", keyCFBundleVersion},
+	} {
+		v, ok := data[property.key].(string)
+		if !ok || v == "" || strings.Contains(v, "$") {
+			continue
+		}
+
+		if _, err := ParseVersion(v); err != nil {
+			r.AddError(property.path, CodeInvalidVersion, property.key, err)
+		}
+	}
+
+	return r
+}
+
+// VersionReport compares the versions against a previously shipped property
+// list, i.e. one loaded with `Load`. The version must not be lower than the
+// previous version and the build version must be greater when the version is
+// the same.
+func (p *PropertyList) VersionReport(previous Values) *validation.Report {
+	r := &validation.Report{}
+
+	current := p.Resolve()
+
+	versions := map[string]Version{}
+	for _, property := range []struct {
+		path, key, name string
+		values          Values
+	}{
+		{"VersionShort", keyCFBundleShortVersionString, "current", current},
+		{"Version", keyCFBundleVersion, "current build", current},
+		{"VersionShort", keyCFBundleShortVersionString, "previous", previous},
+		{"Version", keyCFBundleVersion, "previous build", previous},
+	} {
+		v, err := ParseVersion(property.values.stringValue(property.key))
+		if err != nil {
+			r.AddError(property.path, CodeInvalidVersion, property.key, fmt.Errorf("Unable to compare the %v version: %w", property.name, err))
+			continue
+		}
+
+		versions[property.name] = v
+	}
+
+	if !r.Empty() {
+		return r
+	}
+
+	switch versions["current"].Compare(versions["previous"]) {
+	case -1:
+		r.AddError("VersionShort", CodeVersionNotIncreased, keyCFBundleShortVersionString,
+			fmt.Errorf("%w: %v is lower than %v", ErrVersionNotIncreased, versions["current"], versions["previous"]))
+	case 0:
+		if versions["current build"].Compare(versions["previous build"]) <= 0 {
+			r.AddError("Version", CodeVersionNotIncreased, keyCFBundleVersion,
+				fmt.Errorf("%w: build %v is not greater than %v", ErrVersionNotIncreased, versions["current build"], versions["previous build"]))
+		}
+	}
+
+	return r
+}
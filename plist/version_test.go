@@ -0,0 +1,234 @@
+package plist
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/illyabusigin/apptools/xcconfig"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, v := range []string{"1", "1.2", "1.2.3", "10.14.1", "0.0.1"} {
+		version, err := ParseVersion(v)
+		assert.Nil(t, err, v)
+		assert.Equal(t, v, version.String())
+	}
+
+	for _, v := range []string{"", "1.2.3.4", "v1.2", "1.2-beta", "1..2", ".1", "1.", "$(MARKETING_VERSION)"} {
+		_, err := ParseVersion(v)
+		assert.True(t, errors.Is(err, ErrInvalidVersion), v)
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	parse := func(v string) Version {
+		version, err := ParseVersion(v)
+		assert.Nil(t, err)
+		return version
+	}
+
+	assert.Equal(t, 0, parse("1.2").Compare(parse("1.2.0")))
+	assert.Equal(t, -1, parse("1.2.3").Compare(parse("1.10")))
+	assert.Equal(t, 1, parse("2").Compare(parse("1.99.99")))
+	assert.Equal(t, -1, parse("41").Compare(parse("42")))
+}
+
+func TestVersion_Bump(t *testing.T) {
+	v := Version{1, 2, 3}
+
+	assert.Equal(t, "2.0.0", v.BumpMajor().String())
+	assert.Equal(t, "1.3.0", v.BumpMinor().String())
+	assert.Equal(t, "1.2.4", v.BumpPatch().String())
+	assert.Equal(t, "1.2.4", v.BumpLast().String())
+	assert.Equal(t, "1.2.3", v.String())
+
+	assert.Equal(t, "1.1", Version{1}.BumpMinor().String())
+	assert.Equal(t, "1.0.1", Version{1}.BumpPatch().String())
+	assert.Equal(t, "1.2.10", Version{1, 2, 9}.BumpLast().String())
+}
+
+func TestPropertyList_Bump(t *testing.T) {
+	plist := New(PlatformMac)
+	plist.Defaults()
+	plist.BundleName("Best App")
+	plist.VersionShort("1.2.3")
+	plist.Version("41")
+
+	assert.Nil(t, plist.BumpMinor())
+	assert.Nil(t, plist.BumpBuild())
+	assert.Equal(t, "1.3.0", plist.Resolve().VersionShort())
+	assert.Equal(t, "42", plist.Resolve().Version())
+
+	assert.Nil(t, plist.BumpPatch())
+	assert.Nil(t, plist.BumpMajor())
+	assert.Equal(t, "2.0.0", plist.Resolve().VersionShort())
+
+	plist.VersionShort("$(MARKETING_VERSION)")
+	assert.True(t, errors.Is(plist.BumpMajor(), ErrInvalidVersion))
+}
+
+func TestPropertyList_VersionValidation(t *testing.T) {
+	type fields struct {
+		plist func() *PropertyList
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   []string
+	}{
+		{
+			name: "Valid versions should not report any issues",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.VersionShort("1.2.3")
+					plist.Version("42")
+
+					return plist
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Build setting references should not be validated",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.VersionShort("$(MARKETING_VERSION)")
+					plist.Version("$(CURRENT_PROJECT_VERSION)")
+
+					return plist
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "Invalid versions should be reported",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.VersionShort("1.2.3.4")
+					plist.Version("42b")
+
+					return plist
+				},
+			},
+			want: []string{
+				"VersionShort invalid-version CFBundleShortVersionString",
+				"Version invalid-version CFBundleVersion",
+			},
+		},
+		{
+			name: "Expanded build settings should be validated",
+			fields: fields{
+				plist: func() *PropertyList {
+					plist := New(PlatformMac)
+					plist.Defaults()
+					plist.BundleName("Best App")
+					plist.BuildSettings(xcconfig.Settings{
+						"DEVELOPMENT_LANGUAGE":      "en",
+						"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app",
+						"EXECUTABLE_NAME":           "BestApp",
+						"MARKETING_VERSION":         "1.2.beta",
+						"MACOSX_DEPLOYMENT_TARGET":  "10.15",
+					})
+
+					return plist
+				},
+			},
+			want: []string{
+				"VersionShort invalid-version CFBundleShortVersionString",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := []string{}
+			for _, issue := range tt.fields.plist().Report().Errors() {
+				assert.True(t, errors.Is(issue, ErrInvalidVersion))
+				issues = append(issues, issue.Path+" "+issue.Code+" "+issue.Key)
+			}
+
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
+
+func TestPropertyList_VersionReport(t *testing.T) {
+	plist := func(versionShort, version string) *PropertyList {
+		plist := New(PlatformMac)
+		plist.Defaults()
+		plist.BundleName("Best App")
+		plist.VersionShort(versionShort)
+		plist.Version(version)
+
+		return plist
+	}
+
+	out, err := plist("1.2.3", "41").Build()
+	assert.Nil(t, err)
+
+	previous, err := Decode([]byte(out))
+	assert.Nil(t, err)
+	assert.Equal(t, "1.2.3", previous.VersionShort())
+
+	tests := []struct {
+		name         string
+		versionShort string
+		version      string
+		want         []string
+		wantErr      error
+	}{
+		{
+			name:         "Increased build numbers should not report any issues",
+			versionShort: "1.2.3",
+			version:      "42",
+			want:         []string{},
+		},
+		{
+			name:         "Increased versions should reset the build number",
+			versionShort: "1.3",
+			version:      "1",
+			want:         []string{},
+		},
+		{
+			name:         "Unchanged build numbers should be reported",
+			versionShort: "1.2.3",
+			version:      "41",
+			want:         []string{"Version version-not-increased"},
+			wantErr:      ErrVersionNotIncreased,
+		},
+		{
+			name:         "Decreased versions should be reported",
+			versionShort: "1.1.9",
+			version:      "50",
+			want:         []string{"VersionShort version-not-increased"},
+			wantErr:      ErrVersionNotIncreased,
+		},
+		{
+			name:         "Unresolved versions cannot be compared",
+			versionShort: "$(MARKETING_VERSION)",
+			version:      "42",
+			want:         []string{"VersionShort invalid-version"},
+			wantErr:      ErrInvalidVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := []string{}
+			for _, issue := range plist(tt.versionShort, tt.version).VersionReport(previous).Errors() {
+				assert.True(t, errors.Is(issue, tt.wantErr))
+				issues = append(issues, issue.Path+" "+issue.Code)
+			}
+
+			assert.Equal(t, tt.want, issues)
+		})
+	}
+}
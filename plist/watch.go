@@ -0,0 +1,43 @@
+package plist
+
+// WatchApplication specifies a boolean value indicating whether the bundle is
+// a watchOS app. Required on watchOS.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/wkapplication for more information.
+func (p *PropertyList) WatchApplication(v bool) *PropertyList {
+	p.touch(keyWKApplication)
+	p.watchApplication = v
+	return p
+}
+
+// CompanionAppBundleID specifies the bundle ID of the watchOS app's companion
+// iOS app.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/wkcompanionappbundleidentifier for more information.
+func (p *PropertyList) CompanionAppBundleID(id string) *PropertyList {
+	p.touch(keyWKCompanionAppBundleIdentifier)
+	p.companionAppBundleIdentifier = id
+	return p
+}
+
+// RunsIndependentlyOfCompanionApp specifies a boolean value indicating
+// whether the watchOS app can run without its companion iOS app installed.
+// See https://developer.apple.com/documentation/bundleresources/information_property_list/wkrunsindependentlyofcompanionapp for more information.
+func (p *PropertyList) RunsIndependentlyOfCompanionApp(v bool) *PropertyList {
+	p.touch(keyWKRunsIndependentlyOfCompanionApp)
+	p.runsIndependently = &v
+	return p
+}
+
+// applyWatch applies any watchOS specific properties that have been specified.
+func (p *PropertyList) applyWatch(data map[string]interface{}) {
+	if p.watchApplication {
+		data[keyWKApplication] = true
+	}
+
+	if p.companionAppBundleIdentifier != "" {
+		data[keyWKCompanionAppBundleIdentifier] = p.companionAppBundleIdentifier
+	}
+
+	if p.runsIndependently != nil {
+		data[keyWKRunsIndependentlyOfCompanionApp] = *p.runsIndependently
+	}
+}
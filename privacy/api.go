@@ -0,0 +1,161 @@
+package privacy
+
+// APICategory is a category of APIs that require a reason to be declared
+// before they can be used.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_use_of_required_reason_api for more information.
+type APICategory string
+
+// Required reason API categories.
+const (
+	APIFileTimestamp   APICategory = "NSPrivacyAccessedAPICategoryFileTimestamp"
+	APISystemBootTime  APICategory = "NSPrivacyAccessedAPICategorySystemBootTime"
+	APIDiskSpace       APICategory = "NSPrivacyAccessedAPICategoryDiskSpace"
+	APIActiveKeyboards APICategory = "NSPrivacyAccessedAPICategoryActiveKeyboards"
+	APIUserDefaults    APICategory = "NSPrivacyAccessedAPICategoryUserDefaults"
+)
+
+// Reason is an approved reason code for using a required reason API.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_use_of_required_reason_api for more information.
+type Reason string
+
+// File timestamp reasons.
+const (
+	// ReasonFileTimestampDisplay displays file timestamps to the user.
+	ReasonFileTimestampDisplay Reason = "DDA9.1"
+
+	// ReasonFileTimestampContainer accesses the timestamps of files inside
+	// the app container, app group container or CloudKit container.
+	ReasonFileTimestampContainer Reason = "C617.1"
+
+	// ReasonFileTimestampUserGranted accesses the timestamps of files the
+	// user specifically granted access to.
+	ReasonFileTimestampUserGranted Reason = "3B52.1"
+
+	// ReasonFileTimestampSDKWrapper is used by third-party SDKs wrapping file
+	// timestamp APIs for the app.
+	ReasonFileTimestampSDKWrapper Reason = "0A2A.1"
+)
+
+// System boot time reasons.
+const (
+	// ReasonBootTimeElapsed measures the time elapsed between events within
+	// the app.
+	ReasonBootTimeElapsed Reason = "35F9.1"
+
+	// ReasonBootTimeEventTimestamps calculates absolute timestamps for events
+	// within the app.
+	ReasonBootTimeEventTimestamps Reason = "8FFB.1"
+
+	// ReasonBootTimeBugReport includes the boot time in an optional bug report.
+	ReasonBootTimeBugReport Reason = "3D61.1"
+)
+
+// Disk space reasons.
+const (
+	// ReasonDiskSpaceDisplay displays disk space information to the user.
+	ReasonDiskSpaceDisplay Reason = "85F4.1"
+
+	// ReasonDiskSpaceWrite checks there is sufficient space before writing
+	// files.
+	ReasonDiskSpaceWrite Reason = "E174.1"
+
+	// ReasonDiskSpaceBugReport includes disk space information in an optional
+	// bug report.
+	ReasonDiskSpaceBugReport Reason = "7D9E.1"
+
+	// ReasonDiskSpaceHealthResearch is used by health research apps.
+	ReasonDiskSpaceHealthResearch Reason = "B728.1"
+)
+
+// Active keyboard reasons.
+const (
+	// ReasonKeyboardsCustomKeyboard is used by custom keyboard apps.
+	ReasonKeyboardsCustomKeyboard Reason = "3EC4.1"
+
+	// ReasonKeyboardsCustomizeUI customizes the user interface for the active
+	// keyboards.
+	ReasonKeyboardsCustomizeUI Reason = "54BD.1"
+)
+
+// User defaults reasons.
+const (
+	// ReasonUserDefaultsApp accesses information only available to the app.
+	ReasonUserDefaultsApp Reason = "CA92.1"
+
+	// ReasonUserDefaultsAppGroup accesses information shared with the apps,
+	// app extensions and App Clips of the same app group.
+	ReasonUserDefaultsAppGroup Reason = "1C8F.1"
+
+	// ReasonUserDefaultsSDKWrapper is used by third-party SDKs wrapping user
+	// defaults APIs for the app.
+	ReasonUserDefaultsSDKWrapper Reason = "C56D.1"
+
+	// ReasonUserDefaultsManagedConfiguration accesses MDM managed app
+	// configuration.
+	ReasonUserDefaultsManagedConfiguration Reason = "AC6B.1"
+)
+
+// apiReasons contains the reasons approved for each API category.
+var apiReasons = map[APICategory][]Reason{
+	APIFileTimestamp: {
+		ReasonFileTimestampDisplay,
+		ReasonFileTimestampContainer,
+		ReasonFileTimestampUserGranted,
+		ReasonFileTimestampSDKWrapper,
+	},
+	APISystemBootTime: {
+		ReasonBootTimeElapsed,
+		ReasonBootTimeEventTimestamps,
+		ReasonBootTimeBugReport,
+	},
+	APIDiskSpace: {
+		ReasonDiskSpaceDisplay,
+		ReasonDiskSpaceWrite,
+		ReasonDiskSpaceBugReport,
+		ReasonDiskSpaceHealthResearch,
+	},
+	APIActiveKeyboards: {
+		ReasonKeyboardsCustomKeyboard,
+		ReasonKeyboardsCustomizeUI,
+	},
+	APIUserDefaults: {
+		ReasonUserDefaultsApp,
+		ReasonUserDefaultsAppGroup,
+		ReasonUserDefaultsSDKWrapper,
+		ReasonUserDefaultsManagedConfiguration,
+	},
+}
+
+// Reasons returns the reasons approved for an API category.
+func (c APICategory) Reasons() []Reason {
+	return apiReasons[c]
+}
+
+// allows returns true if the reason is approved for the API category.
+func (c APICategory) allows(reason Reason) bool {
+	for _, r := range apiReasons[c] {
+		if r == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccessedAPI describes the use of a required reason API category.
+type AccessedAPI struct {
+	category APICategory
+	reasons  []Reason
+}
+
+func (a *AccessedAPI) build() map[string]interface{} {
+	reasons := make([]string, len(a.reasons))
+	for idx, reason := range a.reasons {
+		reasons[idx] = string(reason)
+	}
+
+	return map[string]interface{}{
+		keyAccessedAPIType:        string(a.category),
+		keyAccessedAPITypeReasons: reasons,
+	}
+}
@@ -0,0 +1,161 @@
+package privacy
+
+// DataType is a category of data collected by the app or third-party SDK.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_data_use_in_privacy_manifests for more information.
+type DataType string
+
+// Contact info
+const (
+	DataName                 DataType = "NSPrivacyCollectedDataTypeName"
+	DataEmailAddress         DataType = "NSPrivacyCollectedDataTypeEmailAddress"
+	DataPhoneNumber          DataType = "NSPrivacyCollectedDataTypePhoneNumber"
+	DataPhysicalAddress      DataType = "NSPrivacyCollectedDataTypePhysicalAddress"
+	DataOtherUserContactInfo DataType = "NSPrivacyCollectedDataTypeOtherUserContactInfo"
+)
+
+// Health and fitness
+const (
+	DataHealth  DataType = "NSPrivacyCollectedDataTypeHealth"
+	DataFitness DataType = "NSPrivacyCollectedDataTypeFitness"
+)
+
+// Financial info
+const (
+	DataPaymentInfo        DataType = "NSPrivacyCollectedDataTypePaymentInfo"
+	DataCreditInfo         DataType = "NSPrivacyCollectedDataTypeCreditInfo"
+	DataOtherFinancialInfo DataType = "NSPrivacyCollectedDataTypeOtherFinancialInfo"
+)
+
+// Location
+const (
+	DataPreciseLocation DataType = "NSPrivacyCollectedDataTypePreciseLocation"
+	DataCoarseLocation  DataType = "NSPrivacyCollectedDataTypeCoarseLocation"
+)
+
+// Sensitive info and contacts
+const (
+	DataSensitiveInfo DataType = "NSPrivacyCollectedDataTypeSensitiveInfo"
+	DataContacts      DataType = "NSPrivacyCollectedDataTypeContacts"
+)
+
+// User content
+const (
+	DataEmailsOrTextMessages DataType = "NSPrivacyCollectedDataTypeEmailsOrTextMessages"
+	DataPhotosOrVideos       DataType = "NSPrivacyCollectedDataTypePhotosorVideos"
+	DataAudio                DataType = "NSPrivacyCollectedDataTypeAudioData"
+	DataGameplayContent      DataType = "NSPrivacyCollectedDataTypeGameplayContent"
+	DataCustomerSupport      DataType = "NSPrivacyCollectedDataTypeCustomerSupport"
+	DataOtherUserContent     DataType = "NSPrivacyCollectedDataTypeOtherUserContent"
+)
+
+// Browsing and search history
+const (
+	DataBrowsingHistory DataType = "NSPrivacyCollectedDataTypeBrowsingHistory"
+	DataSearchHistory   DataType = "NSPrivacyCollectedDataTypeSearchHistory"
+)
+
+// Identifiers
+const (
+	DataUserID   DataType = "NSPrivacyCollectedDataTypeUserID"
+	DataDeviceID DataType = "NSPrivacyCollectedDataTypeDeviceID"
+)
+
+// Purchases and usage data
+const (
+	DataPurchaseHistory    DataType = "NSPrivacyCollectedDataTypePurchaseHistory"
+	DataProductInteraction DataType = "NSPrivacyCollectedDataTypeProductInteraction"
+	DataAdvertisingData    DataType = "NSPrivacyCollectedDataTypeAdvertisingData"
+	DataOtherUsageData     DataType = "NSPrivacyCollectedDataTypeOtherUsageData"
+)
+
+// Diagnostics
+const (
+	DataCrashData           DataType = "NSPrivacyCollectedDataTypeCrashData"
+	DataPerformanceData     DataType = "NSPrivacyCollectedDataTypePerformanceData"
+	DataOtherDiagnosticData DataType = "NSPrivacyCollectedDataTypeOtherDiagnosticData"
+)
+
+// Surroundings, body and other data
+const (
+	DataEnvironmentScanning DataType = "NSPrivacyCollectedDataTypeEnvironmentScanning"
+	DataHands               DataType = "NSPrivacyCollectedDataTypeHands"
+	DataHead                DataType = "NSPrivacyCollectedDataTypeHead"
+	DataOtherDataTypes      DataType = "NSPrivacyCollectedDataTypeOtherDataTypes"
+)
+
+var dataTypes = map[DataType]bool{
+	DataName: true, DataEmailAddress: true, DataPhoneNumber: true, DataPhysicalAddress: true,
+	DataOtherUserContactInfo: true, DataHealth: true, DataFitness: true, DataPaymentInfo: true,
+	DataCreditInfo: true, DataOtherFinancialInfo: true, DataPreciseLocation: true, DataCoarseLocation: true,
+	DataSensitiveInfo: true, DataContacts: true, DataEmailsOrTextMessages: true, DataPhotosOrVideos: true,
+	DataAudio: true, DataGameplayContent: true, DataCustomerSupport: true, DataOtherUserContent: true,
+	DataBrowsingHistory: true, DataSearchHistory: true, DataUserID: true, DataDeviceID: true,
+	DataPurchaseHistory: true, DataProductInteraction: true, DataAdvertisingData: true, DataOtherUsageData: true,
+	DataCrashData: true, DataPerformanceData: true, DataOtherDiagnosticData: true, DataEnvironmentScanning: true,
+	DataHands: true, DataHead: true, DataOtherDataTypes: true,
+}
+
+// Purpose is a reason the app or third-party SDK collects a data type.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_data_use_in_privacy_manifests for more information.
+type Purpose string
+
+// Purposes for collecting data.
+const (
+	PurposeThirdPartyAdvertising  Purpose = "NSPrivacyCollectedDataTypePurposeThirdPartyAdvertising"
+	PurposeDeveloperAdvertising   Purpose = "NSPrivacyCollectedDataTypePurposeDeveloperAdvertising"
+	PurposeAnalytics              Purpose = "NSPrivacyCollectedDataTypePurposeAnalytics"
+	PurposeProductPersonalization Purpose = "NSPrivacyCollectedDataTypePurposeProductPersonalization"
+	PurposeAppFunctionality       Purpose = "NSPrivacyCollectedDataTypePurposeAppFunctionality"
+	PurposeOther                  Purpose = "NSPrivacyCollectedDataTypePurposeOther"
+)
+
+var purposes = map[Purpose]bool{
+	PurposeThirdPartyAdvertising:  true,
+	PurposeDeveloperAdvertising:   true,
+	PurposeAnalytics:              true,
+	PurposeProductPersonalization: true,
+	PurposeAppFunctionality:       true,
+	PurposeOther:                  true,
+}
+
+// CollectedData describes a type of data collected by the app or third-party
+// SDK.
+type CollectedData struct {
+	dataType DataType
+	linked   bool
+	tracking bool
+	purposes []Purpose
+}
+
+// Linked specifies whether the data is linked to the user's identity.
+func (d *CollectedData) Linked(v bool) *CollectedData {
+	d.linked = v
+	return d
+}
+
+// Tracking specifies whether the data is used to track the user.
+func (d *CollectedData) Tracking(v bool) *CollectedData {
+	d.tracking = v
+	return d
+}
+
+// Purposes specifies the reasons the data is collected. At least one purpose
+// is required.
+func (d *CollectedData) Purposes(purposes ...Purpose) *CollectedData {
+	d.purposes = append(d.purposes, purposes...)
+	return d
+}
+
+func (d *CollectedData) build() map[string]interface{} {
+	purposes := make([]string, len(d.purposes))
+	for idx, purpose := range d.purposes {
+		purposes[idx] = string(purpose)
+	}
+
+	return map[string]interface{}{
+		keyCollectedDataType:         string(d.dataType),
+		keyCollectedDataTypeLinked:   d.linked,
+		keyCollectedDataTypeTracking: d.tracking,
+		keyCollectedDataTypePurposes: purposes,
+	}
+}
@@ -0,0 +1,267 @@
+// Package privacy provides a builder for privacy manifests
+// (`PrivacyInfo.xcprivacy`) declaring the tracking domains, collected data
+// types and required reason APIs used by an app or third-party SDK.
+package privacy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/illyabusigin/apptools/validation"
+	"howett.net/plist"
+)
+
+const (
+	keyTracking                  = "NSPrivacyTracking"
+	keyTrackingDomains           = "NSPrivacyTrackingDomains"
+	keyCollectedDataTypes        = "NSPrivacyCollectedDataTypes"
+	keyCollectedDataType         = "NSPrivacyCollectedDataType"
+	keyCollectedDataTypeLinked   = "NSPrivacyCollectedDataTypeLinked"
+	keyCollectedDataTypeTracking = "NSPrivacyCollectedDataTypeTracking"
+	keyCollectedDataTypePurposes = "NSPrivacyCollectedDataTypePurposes"
+	keyAccessedAPITypes          = "NSPrivacyAccessedAPITypes"
+	keyAccessedAPIType           = "NSPrivacyAccessedAPIType"
+	keyAccessedAPITypeReasons    = "NSPrivacyAccessedAPITypeReasons"
+)
+
+// Validation codes reported by the privacy manifest builder.
+const (
+	CodeMissingProperty      = validation.CodeMissingProperty
+	CodeUnknownDataType      = "unknown-data-type"
+	CodeUnknownPurpose       = "unknown-purpose"
+	CodeUnknownAPICategory   = "unknown-api-category"
+	CodeInvalidReason        = "invalid-reason"
+	CodeDuplicateDeclaration = "duplicate-declaration"
+	CodeInconsistentTracking = "inconsistent-tracking"
+)
+
+var (
+	// ErrMissingRequiredProperty is the error returned for missing properties.
+	ErrMissingRequiredProperty = validation.ErrMissingRequiredProperty
+
+	// ErrUnknownDataType is the error returned for unknown data types.
+	ErrUnknownDataType = errors.New("Unknown collected data type")
+
+	// ErrUnknownPurpose is the error returned for unknown purposes.
+	ErrUnknownPurpose = errors.New("Unknown collection purpose")
+
+	// ErrUnknownAPICategory is the error returned for unknown required reason
+	// API categories.
+	ErrUnknownAPICategory = errors.New("Unknown API category")
+
+	// ErrInvalidReason is the error returned for reasons that are not approved
+	// for an API category.
+	ErrInvalidReason = errors.New("Reason is not approved for the API category")
+
+	// ErrDuplicateDeclaration is the error returned for data types and API
+	// categories declared more than once.
+	ErrDuplicateDeclaration = errors.New("Declared more than once")
+
+	// ErrTrackingWithoutDomains is the warning returned when tracking is
+	// enabled without any tracking domains.
+	ErrTrackingWithoutDomains = errors.New("Tracking is enabled without any tracking domains")
+
+	// ErrTrackingDisabled is the warning returned when data is used for
+	// tracking while tracking is disabled.
+	ErrTrackingDisabled = errors.New("Data is used for tracking but tracking is disabled")
+)
+
+// Manifest is a builder for privacy manifests.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files for more information.
+type Manifest struct {
+	skipValidation bool
+
+	tracking        bool
+	trackingDomains []string
+	collectedData   []*CollectedData
+	accessedAPIs    []*AccessedAPI
+}
+
+// New returns a new privacy `Manifest` builder.
+func New() *Manifest {
+	return &Manifest{}
+}
+
+// SkipValidation will skip all validation when building the manifest.
+func (m *Manifest) SkipValidation() *Manifest {
+	m.skipValidation = true
+	return m
+}
+
+// Tracking specifies whether the app or third-party SDK uses data for
+// tracking as defined under the App Tracking Transparency framework.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files for more information.
+func (m *Manifest) Tracking(v bool) *Manifest {
+	m.tracking = v
+	return m
+}
+
+// TrackingDomains specifies the internet domains the app or third-party SDK
+// connects to that engage in tracking.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files for more information.
+func (m *Manifest) TrackingDomains(domains ...string) *Manifest {
+	m.trackingDomains = append(m.trackingDomains, domains...)
+	return m
+}
+
+// CollectedData declares a type of data the app or third-party SDK collects.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_data_use_in_privacy_manifests for more information.
+func (m *Manifest) CollectedData(dataType DataType, f func(d *CollectedData)) *Manifest {
+	d := &CollectedData{dataType: dataType}
+	f(d)
+	m.collectedData = append(m.collectedData, d)
+	return m
+}
+
+// AccessedAPI declares the use of a required reason API category along with
+// the reasons it is used.
+// See https://developer.apple.com/documentation/bundleresources/privacy_manifest_files/describing_use_of_required_reason_api for more information.
+func (m *Manifest) AccessedAPI(category APICategory, reasons ...Reason) *Manifest {
+	m.accessedAPIs = append(m.accessedAPIs, &AccessedAPI{category: category, reasons: reasons})
+	return m
+}
+
+// IsTracking returns true if the app or third-party SDK declares it uses data
+// for tracking, see `Tracking`.
+func (m *Manifest) IsTracking() bool {
+	return m.tracking
+}
+
+// CollectedDataTypes returns the data types declared as collected, in the
+// order they were declared, see `CollectedData`.
+func (m *Manifest) CollectedDataTypes() []DataType {
+	dataTypes := make([]DataType, len(m.collectedData))
+	for idx, d := range m.collectedData {
+		dataTypes[idx] = d.dataType
+	}
+
+	return dataTypes
+}
+
+// Validate will validate the manifest and return any errors found.
+func (m *Manifest) Validate() error {
+	return m.Report().Err()
+}
+
+// Report will validate the manifest and return a report containing every
+// issue found, including warnings.
+func (m *Manifest) Report() *validation.Report {
+	r := &validation.Report{}
+
+	if m.tracking && len(m.trackingDomains) == 0 {
+		r.AddWarning("TrackingDomains", CodeInconsistentTracking, keyTrackingDomains, ErrTrackingWithoutDomains)
+	}
+
+	seenData := map[DataType]bool{}
+	for idx, d := range m.collectedData {
+		path := validation.Index("CollectedData", idx)
+
+		if !dataTypes[d.dataType] {
+			r.AddError(path, CodeUnknownDataType, keyCollectedDataType, fmt.Errorf("%w: %q", ErrUnknownDataType, d.dataType))
+		}
+
+		if seenData[d.dataType] {
+			r.AddError(path, CodeDuplicateDeclaration, keyCollectedDataType, fmt.Errorf("%w: %v", ErrDuplicateDeclaration, d.dataType))
+		}
+		seenData[d.dataType] = true
+
+		if len(d.purposes) == 0 {
+			r.AddError(validation.Join(path, "Purposes"), CodeMissingProperty, keyCollectedDataTypePurposes, ErrMissingRequiredProperty)
+		}
+
+		for pIdx, purpose := range d.purposes {
+			if !purposes[purpose] {
+				r.AddError(validation.Join(path, validation.Index("Purposes", pIdx)), CodeUnknownPurpose,
+					keyCollectedDataTypePurposes, fmt.Errorf("%w: %q", ErrUnknownPurpose, purpose))
+			}
+		}
+
+		if d.tracking && !m.tracking {
+			r.AddWarning(validation.Join(path, "Tracking"), CodeInconsistentTracking, keyCollectedDataTypeTracking, ErrTrackingDisabled)
+		}
+	}
+
+	seenAPIs := map[APICategory]bool{}
+	for idx, a := range m.accessedAPIs {
+		path := validation.Index("AccessedAPIs", idx)
+
+		if _, found := apiReasons[a.category]; !found {
+			r.AddError(path, CodeUnknownAPICategory, keyAccessedAPIType, fmt.Errorf("%w: %q", ErrUnknownAPICategory, a.category))
+			continue
+		}
+
+		if seenAPIs[a.category] {
+			r.AddError(path, CodeDuplicateDeclaration, keyAccessedAPIType, fmt.Errorf("%w: %v", ErrDuplicateDeclaration, a.category))
+		}
+		seenAPIs[a.category] = true
+
+		if len(a.reasons) == 0 {
+			r.AddError(validation.Join(path, "Reasons"), CodeMissingProperty, keyAccessedAPITypeReasons, ErrMissingRequiredProperty)
+		}
+
+		for rIdx, reason := range a.reasons {
+			if !a.category.allows(reason) {
+				r.AddError(validation.Join(path, validation.Index("Reasons", rIdx)), CodeInvalidReason,
+					keyAccessedAPITypeReasons, fmt.Errorf("%w: %v is not valid for %v", ErrInvalidReason, reason, a.category))
+			}
+		}
+	}
+
+	return r
+}
+
+func (m *Manifest) build() map[string]interface{} {
+	trackingDomains := m.trackingDomains
+	if trackingDomains == nil {
+		trackingDomains = []string{}
+	}
+
+	collectedData := make([]map[string]interface{}, len(m.collectedData))
+	for idx, d := range m.collectedData {
+		collectedData[idx] = d.build()
+	}
+
+	accessedAPIs := make([]map[string]interface{}, len(m.accessedAPIs))
+	for idx, a := range m.accessedAPIs {
+		accessedAPIs[idx] = a.build()
+	}
+
+	return map[string]interface{}{
+		keyTracking:           m.tracking,
+		keyTrackingDomains:    trackingDomains,
+		keyCollectedDataTypes: collectedData,
+		keyAccessedAPITypes:   accessedAPIs,
+	}
+}
+
+// Build will build the privacy manifest property list.
+func (m *Manifest) Build() (string, error) {
+	if !m.skipValidation {
+		if err := m.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	buf := bytes.Buffer{}
+
+	encoder := plist.NewEncoder(&buf)
+	if err := encoder.Encode(m.build()); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Write the privacy manifest to the specified io.Writer.
+func (m *Manifest) Write(w io.Writer) error {
+	data, err := m.Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write([]byte(data))
+
+	return err
+}
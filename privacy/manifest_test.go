@@ -0,0 +1,103 @@
+package privacy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/illyabusigin/apptools/validation"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestManifest_Build(t *testing.T) {
+	m := New()
+	m.Tracking(true).TrackingDomains("ads.best.app")
+	m.CollectedData(DataEmailAddress, func(d *CollectedData) {
+		d.Linked(true).Purposes(PurposeAppFunctionality, PurposeAnalytics)
+	})
+	m.AccessedAPI(APIUserDefaults, ReasonUserDefaultsApp)
+
+	out, err := m.Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>NSPrivacyTracking</key><true/>")
+	assert.Contains(t, out, "<key>NSPrivacyTrackingDomains</key><array><string>ads.best.app</string></array>")
+	assert.Contains(t, out, "<key>NSPrivacyCollectedDataType</key><string>NSPrivacyCollectedDataTypeEmailAddress</string>")
+	assert.Contains(t, out, "<string>NSPrivacyCollectedDataTypePurposeAppFunctionality</string><string>NSPrivacyCollectedDataTypePurposeAnalytics</string>")
+	assert.Contains(t, out, "<key>NSPrivacyAccessedAPIType</key><string>NSPrivacyAccessedAPICategoryUserDefaults</string>")
+	assert.Contains(t, out, "<key>NSPrivacyAccessedAPITypeReasons</key><array><string>CA92.1</string></array>")
+
+	assert.True(t, m.IsTracking())
+	assert.Equal(t, []DataType{DataEmailAddress}, m.CollectedDataTypes())
+
+	buf := bytes.Buffer{}
+	assert.Nil(t, m.Write(&buf))
+	assert.Equal(t, out, buf.String())
+}
+
+func TestManifest_EmptyBuild(t *testing.T) {
+	out, err := New().Build()
+	assert.Nil(t, err)
+	assert.Contains(t, out, "<key>NSPrivacyTracking</key><false/>")
+	assert.Contains(t, out, "<key>NSPrivacyTrackingDomains</key><array></array>")
+}
+
+func TestManifest_Report(t *testing.T) {
+	m := New()
+	m.Tracking(true)
+	m.CollectedData(DataCrashData, func(d *CollectedData) {})
+	m.CollectedData(DataCrashData, func(d *CollectedData) {
+		d.Purposes("Fun")
+	})
+	m.CollectedData("Shoe size", func(d *CollectedData) {
+		d.Purposes(PurposeOther)
+	})
+	m.AccessedAPI(APIDiskSpace, ReasonDiskSpaceWrite, ReasonUserDefaultsApp)
+	m.AccessedAPI(APIFileTimestamp)
+	m.AccessedAPI("Camera", "1234.1")
+
+	report := m.Report()
+
+	issues := []string{}
+	for _, issue := range report.Issues {
+		issues = append(issues, fmt.Sprintf("%v %v %v", issue.Severity, issue.Path, issue.Code))
+	}
+
+	assert.Equal(t, []string{
+		"warning TrackingDomains inconsistent-tracking",
+		"error CollectedData[0].Purposes missing-property",
+		"error CollectedData[1] duplicate-declaration",
+		"error CollectedData[1].Purposes[0] unknown-purpose",
+		"error CollectedData[2] unknown-data-type",
+		"error AccessedAPIs[0].Reasons[1] invalid-reason",
+		"error AccessedAPIs[1].Reasons missing-property",
+		"error AccessedAPIs[2] unknown-api-category",
+	}, issues)
+	assert.True(t, errors.Is(report, ErrInvalidReason))
+	assert.True(t, errors.Is(report, validation.ErrMissingRequiredProperty))
+
+	_, err := m.Build()
+	assert.NotNil(t, err)
+
+	_, err = m.SkipValidation().Build()
+	assert.Nil(t, err)
+}
+
+func TestManifest_TrackingDisabled(t *testing.T) {
+	m := New()
+	m.CollectedData(DataDeviceID, func(d *CollectedData) {
+		d.Tracking(true).Purposes(PurposeThirdPartyAdvertising)
+	})
+
+	report := m.Report()
+	assert.Nil(t, report.Err())
+	assert.Len(t, report.Warnings(), 1)
+	assert.Equal(t, "CollectedData[0].Tracking", report.Warnings()[0].Path)
+}
+
+func TestAPICategory_Reasons(t *testing.T) {
+	assert.Equal(t, []Reason{ReasonKeyboardsCustomKeyboard, ReasonKeyboardsCustomizeUI}, APIActiveKeyboards.Reasons())
+	assert.True(t, APIUserDefaults.allows(ReasonUserDefaultsAppGroup))
+	assert.False(t, APIUserDefaults.allows(ReasonDiskSpaceWrite))
+	assert.Empty(t, APICategory("Camera").Reasons())
+}
@@ -0,0 +1,175 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/illyabusigin/apptools/entitlements"
+	"github.com/illyabusigin/apptools/format"
+	"github.com/illyabusigin/apptools/validation"
+	"howett.net/plist"
+)
+
+// Validation codes reported when checking entitlements against a profile.
+const (
+	CodeNotGranted             = "not-granted"
+	CodeValueNotAllowed        = "value-not-allowed"
+	CodeAPSEnvironmentMismatch = "aps-environment-mismatch"
+	CodeWildcardAppID          = "wildcard-app-id"
+	CodeUnresolvedValue        = "unresolved-value"
+)
+
+var (
+	// ErrNotGranted is the error returned for entitlements that are not
+	// granted by the provisioning profile.
+	ErrNotGranted = errors.New("Entitlement is not granted by the provisioning profile")
+
+	// ErrValueNotAllowed is the error returned for entitlement values that
+	// are not allowed by the provisioning profile.
+	ErrValueNotAllowed = errors.New("Value is not allowed by the provisioning profile")
+
+	// ErrAPSEnvironmentMismatch is the error returned when the push
+	// notification environment differs from the provisioning profile.
+	ErrAPSEnvironmentMismatch = errors.New("Push notification environment does not match the provisioning profile")
+
+	// ErrWildcardAppID is the error returned for capabilities requested with
+	// a provisioning profile issued for a wildcard App ID, which do not
+	// support capabilities.
+	ErrWildcardAppID = errors.New("Entitlement is not supported by wildcard App IDs")
+
+	// ErrUnresolvedValue is the warning returned for values containing build
+	// setting references that cannot be resolved from the profile.
+	ErrUnresolvedValue = errors.New("Value contains a build setting reference and cannot be checked")
+)
+
+// Check builds the entitlements and reports the keys and values that are not
+// granted by the provisioning profile. The entitlements output format is
+// ignored.
+func (p *Profile) Check(e *entitlements.Entitlements) (*validation.Report, error) {
+	xml := *e
+	xml.Format(format.XML)
+
+	data, err := xml.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Compare([]byte(data))
+}
+
+// Compare decodes an entitlements property list and reports the keys and
+// values that are not granted by the provisioning profile.
+func (p *Profile) Compare(data []byte) (*validation.Report, error) {
+	requested := map[string]interface{}{}
+	if _, err := plist.Unmarshal(data, &requested); err != nil {
+		return nil, err
+	}
+
+	return p.report(requested), nil
+}
+
+func (p *Profile) report(requested map[string]interface{}) *validation.Report {
+	r := &validation.Report{}
+
+	keys := make([]string, 0, len(requested))
+	for key := range requested {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		// App Sandbox and Hardened Runtime entitlements are not granted by
+		// provisioning profiles.
+		if entitlements.MacOnly(key) {
+			continue
+		}
+
+		granted, found := p.Entitlements[key]
+		switch {
+		case !found && p.Wildcard():
+			r.AddError("", CodeWildcardAppID, key, fmt.Errorf("%w: %v", ErrWildcardAppID, p.ApplicationIdentifier()))
+		case !found:
+			r.AddError("", CodeNotGranted, key, ErrNotGranted)
+		case key == "aps-environment":
+			if requested[key] != granted {
+				r.AddError("", CodeAPSEnvironmentMismatch, key,
+					fmt.Errorf("%w: %v is not %v", ErrAPSEnvironmentMismatch, requested[key], granted))
+			}
+		default:
+			p.compareValue(r, key, requested[key], granted)
+		}
+	}
+
+	return r
+}
+
+func (p *Profile) compareValue(r *validation.Report, key string, requested, granted interface{}) {
+	values, ok := requested.([]interface{})
+	if !ok {
+		values = []interface{}{requested}
+	}
+
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			value = p.expand(s)
+
+			if strings.Contains(value.(string), "$(") {
+				r.AddWarning("", CodeUnresolvedValue, key, fmt.Errorf("%w: %q", ErrUnresolvedValue, s))
+				continue
+			}
+		}
+
+		if !allows(granted, value) {
+			r.AddError("", CodeValueNotAllowed, key, fmt.Errorf("%w: %v", ErrValueNotAllowed, formatValue(value)))
+		}
+	}
+}
+
+// expand replaces the team and App ID prefix build settings with the values
+// from the provisioning profile.
+func (p *Profile) expand(value string) string {
+	if len(p.ApplicationIdentifierPrefix) > 0 {
+		value = strings.Replace(value, "$(AppIdentifierPrefix)", p.ApplicationIdentifierPrefix[0]+".", -1)
+	}
+
+	if len(p.TeamIdentifier) > 0 {
+		value = strings.Replace(value, "$(TeamIdentifierPrefix)", p.TeamIdentifier[0]+".", -1)
+	}
+
+	return value
+}
+
+// allows returns true if the granted profile value allows the requested
+// value. Granted strings may end with a `*` wildcard and granted arrays allow
+// any of their elements.
+func allows(granted, value interface{}) bool {
+	switch g := granted.(type) {
+	case []interface{}:
+		for _, element := range g {
+			if allows(element, value) {
+				return true
+			}
+		}
+		return false
+	case string:
+		s, ok := value.(string)
+		return g == "*" || ok && matchWildcard(g, s)
+	case bool:
+		b, ok := value.(bool)
+		return ok && (g || !b)
+	default:
+		return reflect.DeepEqual(granted, value)
+	}
+}
+
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,88 @@
+package provisioning
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/illyabusigin/apptools/entitlements"
+	"github.com/illyabusigin/apptools/format"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestProfile_Check(t *testing.T) {
+	p, err := Parse([]byte(profileFixture))
+	assert.Nil(t, err)
+
+	e := entitlements.New()
+	e.APS.Development()
+	e.AppGroups.Add("group.com.best.app")
+	e.KeychainAccessGroups.Add("$(AppIdentifierPrefix)com.best.app")
+	e.AssociatedDomains.AppLinks("best.app")
+	e.AppSandbox.NetworkClient()
+	e.Format(format.JSON)
+
+	r, err := p.Check(e)
+	assert.Nil(t, err)
+	assert.Empty(t, r.Issues, "The entitlements output format should be ignored")
+
+	e.APS.Production()
+	e.AppGroups.Add("group.com.best.other")
+	e.HealthKit.Enable()
+	e.KeychainAccessGroups.Add("$(AppIdentifierPrefix)$(PRODUCT_BUNDLE_IDENTIFIER)")
+
+	r, err = p.Check(e)
+	assert.Nil(t, err)
+
+	issues := r.Errors()
+	assert.Len(t, issues, 3)
+	assert.Equal(t, CodeAPSEnvironmentMismatch, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], ErrAPSEnvironmentMismatch))
+	assert.Equal(t, "Push notification environment does not match the provisioning profile: production is not development (aps-environment)", issues[0].Error())
+	assert.Equal(t, CodeNotGranted, issues[1].Code)
+	assert.Equal(t, "com.apple.developer.healthkit", issues[1].Key)
+	assert.Equal(t, CodeValueNotAllowed, issues[2].Code)
+	assert.Equal(t, "com.apple.security.application-groups", issues[2].Key)
+	assert.Contains(t, issues[2].Error(), `"group.com.best.other"`)
+
+	warnings := r.Warnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodeUnresolvedValue, warnings[0].Code)
+	assert.Equal(t, "keychain-access-groups", warnings[0].Key)
+}
+
+func TestProfile_CheckBuildError(t *testing.T) {
+	p, err := Parse([]byte(profileFixture))
+	assert.Nil(t, err)
+
+	_, err = p.Check(entitlements.New())
+	assert.True(t, errors.Is(err, entitlements.ErrNoEntitlements))
+
+	_, err = p.Compare([]byte("not a property list"))
+	assert.NotNil(t, err)
+}
+
+func TestProfile_CompareWildcard(t *testing.T) {
+	p := &Profile{
+		TeamIdentifier: []string{"ABCDE12345"},
+		Entitlements: map[string]interface{}{
+			"application-identifier": "ABCDE12345.*",
+			"get-task-allow":         false,
+		},
+	}
+
+	r, err := p.Compare([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0"><dict>
+<key>aps-environment</key><string>development</string>
+<key>application-identifier</key><string>ABCDE12345.com.best.app</string>
+<key>get-task-allow</key><true/>
+</dict></plist>`))
+	assert.Nil(t, err)
+
+	issues := r.Errors()
+	assert.Len(t, issues, 2)
+	assert.Equal(t, CodeWildcardAppID, issues[0].Code)
+	assert.Equal(t, "aps-environment", issues[0].Key)
+	assert.True(t, errors.Is(issues[0], ErrWildcardAppID))
+	assert.Equal(t, CodeValueNotAllowed, issues[1].Code)
+	assert.Equal(t, "get-task-allow", issues[1].Key)
+}
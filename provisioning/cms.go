@@ -0,0 +1,185 @@
+package provisioning
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCMS is the error returned when the CMS/PKCS#7 wrapper of a
+// provisioning profile cannot be decoded.
+var ErrInvalidCMS = errors.New("Invalid CMS signed data")
+
+const (
+	tagOctetString = 0x04
+	tagSequence    = 0x30
+	tagContext0    = 0xa0
+	constructed    = 0x20
+)
+
+// oidSignedData is the DER encoded object identifier 1.2.840.113549.1.7.2.
+var oidSignedData = []byte{0x06, 0x09, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x07, 0x02}
+
+// berElement is a decoded BER type-length-value element.
+type berElement struct {
+	tag     byte
+	content []byte
+}
+
+func (e berElement) constructed() bool {
+	return e.tag&constructed != 0
+}
+
+// readElement decodes the BER element at the start of b, returning the
+// element and the remaining bytes. Provisioning profiles use indefinite
+// length encoding, which is not supported by `encoding/asn1`.
+func readElement(b []byte) (berElement, []byte, error) {
+	if len(b) < 2 {
+		return berElement{}, nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidCMS)
+	}
+
+	tag := b[0]
+	if tag&0x1f == 0x1f {
+		return berElement{}, nil, fmt.Errorf("%w: high tag numbers are not supported", ErrInvalidCMS)
+	}
+
+	length := int(b[1])
+	b = b[2:]
+
+	switch {
+	case length == 0x80:
+		if tag&constructed == 0 {
+			return berElement{}, nil, fmt.Errorf("%w: indefinite length primitive element", ErrInvalidCMS)
+		}
+
+		rest := b
+		for {
+			if len(rest) >= 2 && rest[0] == 0 && rest[1] == 0 {
+				return berElement{tag: tag, content: b[:len(b)-len(rest)]}, rest[2:], nil
+			}
+
+			var err error
+			if _, rest, err = readElement(rest); err != nil {
+				return berElement{}, nil, err
+			}
+		}
+	case length > 0x80:
+		n := length & 0x7f
+		if n > 4 || len(b) < n {
+			return berElement{}, nil, fmt.Errorf("%w: invalid length", ErrInvalidCMS)
+		}
+
+		length = 0
+		for _, c := range b[:n] {
+			length = length<<8 | int(c)
+		}
+		b = b[n:]
+	}
+
+	if length < 0 || len(b) < length {
+		return berElement{}, nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidCMS)
+	}
+
+	return berElement{tag: tag, content: b[:length]}, b[length:], nil
+}
+
+// children decodes the elements contained in a constructed element.
+func (e berElement) children() ([]berElement, error) {
+	elements := []berElement{}
+
+	rest := e.content
+	for len(rest) > 0 {
+		child, r, err := readElement(rest)
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, child)
+		rest = r
+	}
+
+	return elements, nil
+}
+
+// octets returns the content of an octet string, concatenating the segments
+// of constructed octet strings.
+func (e berElement) octets() ([]byte, error) {
+	if !e.constructed() {
+		return e.content, nil
+	}
+
+	children, err := e.children()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	for _, child := range children {
+		data, err := child.octets()
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// child returns the nth child of a constructed element with the expected tag.
+func (e berElement) child(n int, tag byte) (berElement, error) {
+	children, err := e.children()
+	if err != nil {
+		return berElement{}, err
+	}
+
+	if len(children) <= n || children[n].tag != tag {
+		return berElement{}, fmt.Errorf("%w: unexpected structure", ErrInvalidCMS)
+	}
+
+	return children[n], nil
+}
+
+// unwrapCMS strips the CMS/PKCS#7 signed data wrapper and returns the
+// encapsulated content. The signature is not verified.
+func unwrapCMS(data []byte) ([]byte, error) {
+	contentInfo, _, err := readElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentInfo.tag != tagSequence || !bytes.HasPrefix(contentInfo.content, oidSignedData) {
+		return nil, fmt.Errorf("%w: not a signed data content info", ErrInvalidCMS)
+	}
+
+	// ContentInfo -> [0] -> SignedData -> EncapsulatedContentInfo -> [0] -> OCTET STRING
+	content, err := contentInfo.child(1, tagContext0)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := content.child(0, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	encapsulated, err := signedData.child(2, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	eContent, err := encapsulated.child(1, tagContext0)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := eContent.children()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(children) != 1 || children[0].tag&^constructed != tagOctetString {
+		return nil, fmt.Errorf("%w: missing encapsulated content", ErrInvalidCMS)
+	}
+
+	return children[0].octets()
+}
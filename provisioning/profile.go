@@ -0,0 +1,109 @@
+// Package provisioning provides a parser for provisioning profiles
+// (`.mobileprovision` and `.provisionprofile`) and checks entitlements
+// against the entitlements granted by a profile.
+package provisioning
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"howett.net/plist"
+)
+
+// Profile is a decoded provisioning profile.
+// See https://developer.apple.com/documentation/technotes/tn3125-inside-code-signing-provisioning-profiles for more information.
+type Profile struct {
+	Name                        string                 `plist:"Name"`
+	UUID                        string                 `plist:"UUID"`
+	AppIDName                   string                 `plist:"AppIDName"`
+	TeamName                    string                 `plist:"TeamName"`
+	TeamIdentifier              []string               `plist:"TeamIdentifier"`
+	ApplicationIdentifierPrefix []string               `plist:"ApplicationIdentifierPrefix"`
+	Platform                    []string               `plist:"Platform"`
+	CreationDate                time.Time              `plist:"CreationDate"`
+	ExpirationDate              time.Time              `plist:"ExpirationDate"`
+	ProvisionedDevices          []string               `plist:"ProvisionedDevices"`
+	ProvisionsAllDevices        bool                   `plist:"ProvisionsAllDevices"`
+	Entitlements                map[string]interface{} `plist:"Entitlements"`
+}
+
+// Parse decodes a provisioning profile. The CMS/PKCS#7 wrapper is stripped
+// locally without verifying its signature. Property lists that were already
+// extracted from a profile, i.e. with `security cms -D`, are also accepted.
+func Parse(data []byte) (*Profile, error) {
+	content := data
+	if !isPropertyList(data) {
+		var err error
+		if content, err = unwrapCMS(data); err != nil {
+			return nil, err
+		}
+	}
+
+	profile := &Profile{}
+	if _, err := plist.Unmarshal(content, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// Load reads and decodes the provisioning profile at the specified path.
+func Load(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+func isPropertyList(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return bytes.HasPrefix(data, []byte("<?xml")) || bytes.HasPrefix(data, []byte("<plist")) ||
+		bytes.HasPrefix(data, []byte("bplist"))
+}
+
+// ApplicationIdentifier returns the App ID the profile was issued for, i.e.
+// `ABCDE12345.com.best.app` or `ABCDE12345.*`.
+func (p *Profile) ApplicationIdentifier() string {
+	for _, key := range []string{"application-identifier", "com.apple.application-identifier"} {
+		if id, ok := p.Entitlements[key].(string); ok {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// Wildcard returns true if the profile was issued for a wildcard App ID.
+func (p *Profile) Wildcard() bool {
+	return strings.HasSuffix(p.ApplicationIdentifier(), "*")
+}
+
+// AllowsBundleID returns true if the profile can sign the specified bundle
+// identifier.
+func (p *Profile) AllowsBundleID(bundleID string) bool {
+	id := p.ApplicationIdentifier()
+	if idx := strings.Index(id, "."); idx >= 0 {
+		id = id[idx+1:]
+	}
+
+	return matchWildcard(id, bundleID)
+}
+
+// Expired returns true if the profile expired before the specified time.
+func (p *Profile) Expired(now time.Time) bool {
+	return !p.ExpirationDate.IsZero() && p.ExpirationDate.Before(now)
+}
+
+// matchWildcard returns true if the value matches the pattern, where a
+// trailing `*` in the pattern matches any suffix.
+func matchWildcard(pattern, value string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == value
+}
@@ -0,0 +1,172 @@
+package provisioning
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const profileFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>AppIDName</key>
+	<string>Best App</string>
+	<key>ApplicationIdentifierPrefix</key>
+	<array><string>ABCDE12345</string></array>
+	<key>CreationDate</key>
+	<date>2020-01-01T00:00:00Z</date>
+	<key>Platform</key>
+	<array><string>iOS</string></array>
+	<key>Entitlements</key>
+	<dict>
+		<key>application-identifier</key>
+		<string>ABCDE12345.com.best.app</string>
+		<key>aps-environment</key>
+		<string>development</string>
+		<key>com.apple.developer.associated-domains</key>
+		<string>*</string>
+		<key>com.apple.security.application-groups</key>
+		<array><string>group.com.best.app</string></array>
+		<key>get-task-allow</key>
+		<true/>
+		<key>keychain-access-groups</key>
+		<array><string>ABCDE12345.*</string></array>
+	</dict>
+	<key>ExpirationDate</key>
+	<date>2021-01-01T00:00:00Z</date>
+	<key>Name</key>
+	<string>Best App Development</string>
+	<key>ProvisionedDevices</key>
+	<array><string>00008030-001A2B3C4D5E6F70</string></array>
+	<key>TeamIdentifier</key>
+	<array><string>ABCDE12345</string></array>
+	<key>TeamName</key>
+	<string>Best Team</string>
+	<key>UUID</key>
+	<string>6f1b2c3d-0000-4000-8000-000000000000</string>
+</dict>
+</plist>`
+
+// tlv encodes a BER element using the definite length form.
+func tlv(tag byte, content ...[]byte) []byte {
+	data := []byte{}
+	for _, c := range content {
+		data = append(data, c...)
+	}
+
+	switch n := len(data); {
+	case n < 0x80:
+		return append([]byte{tag, byte(n)}, data...)
+	case n < 0x100:
+		return append([]byte{tag, 0x81, byte(n)}, data...)
+	default:
+		return append([]byte{tag, 0x82, byte(n >> 8), byte(n)}, data...)
+	}
+}
+
+// indefinite encodes a constructed BER element using the indefinite length
+// form used by provisioning profiles.
+func indefinite(tag byte, content ...[]byte) []byte {
+	data := []byte{tag, 0x80}
+	for _, c := range content {
+		data = append(data, c...)
+	}
+
+	return append(data, 0, 0)
+}
+
+// signedData wraps the content in a CMS signed data structure without any
+// certificates or signatures.
+func signedData(content []byte) []byte {
+	half := len(content) / 2
+	oidData := []byte{0x06, 0x09, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x01, 0x07, 0x01}
+
+	return indefinite(0x30,
+		oidSignedData,
+		indefinite(0xa0,
+			indefinite(0x30,
+				tlv(0x02, []byte{1}),
+				tlv(0x31),
+				indefinite(0x30,
+					oidData,
+					indefinite(0xa0,
+						indefinite(0x24, tlv(0x04, content[:half]), tlv(0x04, content[half:])),
+					),
+				),
+				tlv(0x31),
+			),
+		),
+	)
+}
+
+func TestParse(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"CMS":           signedData([]byte(profileFixture)),
+		"DER":           tlv(0x30, oidSignedData, tlv(0xa0, tlv(0x30, tlv(0x02, []byte{1}), tlv(0x31), tlv(0x30, oidSignedData, tlv(0xa0, tlv(0x04, []byte(profileFixture))))))),
+		"Property list": []byte(profileFixture),
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := Parse(data)
+			assert.Nil(t, err)
+			assert.Equal(t, "Best App Development", p.Name)
+			assert.Equal(t, "6f1b2c3d-0000-4000-8000-000000000000", p.UUID)
+			assert.Equal(t, []string{"ABCDE12345"}, p.TeamIdentifier)
+			assert.Equal(t, []string{"00008030-001A2B3C4D5E6F70"}, p.ProvisionedDevices)
+			assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), p.ExpirationDate.UTC())
+			assert.Equal(t, "development", p.Entitlements["aps-environment"])
+			assert.Equal(t, "ABCDE12345.com.best.app", p.ApplicationIdentifier())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte{0x30, 0x80, 0x06})
+	assert.True(t, errors.Is(err, ErrInvalidCMS))
+
+	_, err = Parse(tlv(0x30, tlv(0x02, []byte{1})))
+	assert.True(t, errors.Is(err, ErrInvalidCMS))
+
+	_, err = Parse(tlv(0x30, oidSignedData, tlv(0xa0, tlv(0x30))))
+	assert.True(t, errors.Is(err, ErrInvalidCMS))
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provisioning")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "embedded.mobileprovision")
+	assert.Nil(t, ioutil.WriteFile(path, signedData([]byte(profileFixture)), 0644))
+
+	p, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "Best Team", p.TeamName)
+
+	_, err = Load(filepath.Join(dir, "missing.mobileprovision"))
+	assert.NotNil(t, err)
+}
+
+func TestProfile_AllowsBundleID(t *testing.T) {
+	p := &Profile{Entitlements: map[string]interface{}{"application-identifier": "ABCDE12345.com.best.app"}}
+	assert.False(t, p.Wildcard())
+	assert.True(t, p.AllowsBundleID("com.best.app"))
+	assert.False(t, p.AllowsBundleID("com.best.app.widget"))
+
+	p.Entitlements["application-identifier"] = "ABCDE12345.com.best.*"
+	assert.True(t, p.Wildcard())
+	assert.True(t, p.AllowsBundleID("com.best.app.widget"))
+	assert.False(t, p.AllowsBundleID("com.other.app"))
+}
+
+func TestProfile_Expired(t *testing.T) {
+	p := &Profile{ExpirationDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	assert.True(t, p.Expired(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, p.Expired(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, (&Profile{}).Expired(time.Now()))
+}
@@ -0,0 +1,12 @@
+package validation
+
+import "errors"
+
+// CodeMissingProperty is the validation code reported by every builder for
+// missing required properties.
+const CodeMissingProperty = "missing-property"
+
+// ErrMissingRequiredProperty is the error returned by every builder for
+// missing required properties, so `errors.Is` matches it regardless of the
+// package reporting it.
+var ErrMissingRequiredProperty = errors.New("Missing property")
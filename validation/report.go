@@ -0,0 +1,208 @@
+// Package validation provides a structured validation report shared by the
+// apptools builders. A `Report` collects every issue found while validating a
+// builder, each with a path, a severity, a stable code and, where relevant,
+// the related property list key.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity describes how serious a validation issue is.
+type Severity string
+
+const (
+	// SeverityError is used for issues that prevent a valid build.
+	SeverityError Severity = "error"
+
+	// SeverityWarning is used for issues that do not prevent a build but are
+	// likely mistakes, or are known to be rejected during App Review.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a single validation problem.
+type Issue struct {
+	// Path identifies the offending builder property, i.e. `colors[2].appearance`.
+	Path string `json:"path,omitempty"`
+
+	// Severity of the issue.
+	Severity Severity `json:"severity"`
+
+	// Code is a stable, machine readable identifier for the kind of issue.
+	Code string `json:"code"`
+
+	// Key is the related property list key, if any.
+	Key string `json:"key,omitempty"`
+
+	// Message is a human readable description of the issue.
+	Message string `json:"message"`
+
+	err error
+}
+
+// Error returns the human readable description of the issue including its
+// path and key.
+func (i Issue) Error() string {
+	msg := i.Message
+	if i.Key != "" {
+		msg = fmt.Sprintf("%v (%v)", msg, i.Key)
+	}
+
+	if i.Path == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%v: %v", i.Path, msg)
+}
+
+// Unwrap returns the underlying error, allowing sentinel errors to be matched
+// with `errors.Is`.
+func (i Issue) Unwrap() error {
+	return i.err
+}
+
+// Report collects validation issues. The zero value is an empty report ready
+// to use. Report implements `error` so it can be returned from `Validate`
+// functions; use `Err` to only return it when it contains errors.
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Add adds an issue to the report for the provided error.
+func (r *Report) Add(severity Severity, path, code, key string, err error) {
+	r.Issues = append(r.Issues, Issue{
+		Path:     path,
+		Severity: severity,
+		Code:     code,
+		Key:      key,
+		Message:  err.Error(),
+		err:      err,
+	})
+}
+
+// AddError adds an error to the report.
+func (r *Report) AddError(path, code, key string, err error) {
+	r.Add(SeverityError, path, code, key, err)
+}
+
+// AddWarning adds a warning to the report.
+func (r *Report) AddWarning(path, code, key string, err error) {
+	r.Add(SeverityWarning, path, code, key, err)
+}
+
+// Merge adds every issue from another report, prefixing their paths with the
+// provided prefix.
+func (r *Report) Merge(prefix string, other *Report) {
+	if other == nil {
+		return
+	}
+
+	for _, issue := range other.Issues {
+		issue.Path = Join(prefix, issue.Path)
+		r.Issues = append(r.Issues, issue)
+	}
+}
+
+// Join joins two path components, i.e. `colors[2]` and `appearance` become
+// `colors[2].appearance`.
+func Join(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
+
+// Index returns the path for an element of a list, i.e. `colors[2]`.
+func Index(path string, idx int) string {
+	return fmt.Sprintf("%v[%d]", path, idx)
+}
+
+// Errors returns the issues with an error severity.
+func (r *Report) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the issues with a warning severity.
+func (r *Report) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+func (r *Report) filter(severity Severity) []Issue {
+	issues := []Issue{}
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// HasErrors returns a boolean value indicating whether or not the report
+// contains any errors.
+func (r *Report) HasErrors() bool {
+	return len(r.Errors()) > 0
+}
+
+// Empty returns a boolean value indicating whether or not the report contains
+// any issues.
+func (r *Report) Empty() bool {
+	return len(r.Issues) == 0
+}
+
+// Err returns the report as an error if it contains any errors, otherwise nil.
+// Warnings alone never cause an error.
+func (r *Report) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+
+	return r
+}
+
+// Error returns every issue in the report, one per line. A report containing
+// a single error returns only that error.
+func (r *Report) Error() string {
+	if len(r.Issues) == 1 {
+		return r.Issues[0].Error()
+	}
+
+	lines := make([]string, len(r.Issues))
+	for idx, issue := range r.Issues {
+		lines[idx] = fmt.Sprintf("  %v: %v", issue.Severity, issue.Error())
+	}
+
+	return fmt.Sprintf("%d validation issues:\n%v", len(r.Issues), strings.Join(lines, "\n"))
+}
+
+// Is returns true if any issue in the report matches the target error,
+// allowing `errors.Is(err, plist.ErrMissingRequiredProperty)` to be used on a
+// report.
+func (r *Report) Is(target error) bool {
+	for _, issue := range r.Issues {
+		if errors.Is(issue, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JSON returns the report encoded as indented JSON, suitable for CI tooling.
+func (r *Report) JSON() ([]byte, error) {
+	issues := r.Issues
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	return json.MarshalIndent(Report{Issues: issues}, "", "  ")
+}
@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("Missing property")
+
+func TestReport_Err(t *testing.T) {
+	r := &Report{}
+	assert.Nil(t, r.Err(), "Empty reports should not return an error")
+
+	r.AddWarning("DisplayName", "placeholder", "CFBundleDisplayName", errors.New("Looks like a placeholder"))
+	assert.Nil(t, r.Err(), "Warnings should not return an error")
+	assert.False(t, r.HasErrors())
+
+	r.AddError("BundleID", "missing-property", "CFBundleIdentifier", errTest)
+	assert.NotNil(t, r.Err())
+	assert.True(t, errors.Is(r.Err(), errTest))
+	assert.Len(t, r.Errors(), 1)
+	assert.Len(t, r.Warnings(), 1)
+}
+
+func TestReport_Error(t *testing.T) {
+	r := &Report{}
+	r.AddError("BundleID", "missing-property", "CFBundleIdentifier", errTest)
+	assert.Equal(t, "BundleID: Missing property (CFBundleIdentifier)", r.Error())
+
+	r.AddError("", "missing-property", "", errTest)
+	expected := `2 validation issues:
+  error: BundleID: Missing property (CFBundleIdentifier)
+  error: Missing property`
+	assert.Equal(t, expected, r.Error())
+}
+
+func TestReport_Merge(t *testing.T) {
+	child := &Report{}
+	child.AddError("appearance", "overlap", "", errTest)
+	child.AddError("", "invalid", "", errTest)
+
+	r := &Report{}
+	r.Merge(Index("colors", 2), child)
+	r.Merge("ignored", nil)
+
+	assert.Equal(t, "colors[2].appearance", r.Issues[0].Path)
+	assert.Equal(t, "colors[2]", r.Issues[1].Path)
+	assert.Equal(t, "colors[2]", Join("colors", "[2]"))
+}
+
+func TestReport_JSON(t *testing.T) {
+	r := &Report{}
+
+	data, err := r.JSON()
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"issues": []}`, string(data))
+
+	r.AddError("BundleID", "missing-property", "CFBundleIdentifier", errTest)
+	data, err = r.JSON()
+	assert.Nil(t, err)
+
+	decoded := Report{}
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, Issue{
+		Path:     "BundleID",
+		Severity: SeverityError,
+		Code:     "missing-property",
+		Key:      "CFBundleIdentifier",
+		Message:  "Missing property",
+	}, decoded.Issues[0])
+}
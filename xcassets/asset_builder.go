@@ -3,9 +3,10 @@ package xcassets
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/nfnt/resize"
 )
 
 // Asset creates a named image set with the specified name, returning an
@@ -29,8 +30,12 @@ type AssetBuilder struct {
 	defs []*AssetDefinition
 	name string
 
+	interpolation  *resize.InterpolationFunction
+	maxScaleFactor int
+
 	Gamut      Gamut
 	Properties AssetProperties
+	Encoding   ImageEncoding
 }
 
 // Asset specifies the asset definition. Certain properties are set by default
@@ -46,29 +51,57 @@ func (b *AssetBuilder) Asset(f func(d *AssetDefinition)) *AssetBuilder {
 	return b
 }
 
-// Validate the asset set configuration.
+// Interpolation specifies the default resampling filter for every asset
+// definition in the image set. Definitions can override it with
+// `d.Source.Interpolation`.
+func (b *AssetBuilder) Interpolation(f resize.InterpolationFunction) *AssetBuilder {
+	b.interpolation = &f
+	return b
+}
+
+// MaxScaleFactor specifies the default maximum scale factor for every asset
+// definition in the image set. Definitions can override it with
+// `d.Source.MaxScaleFactor`.
+func (b *AssetBuilder) MaxScaleFactor(v uint) *AssetBuilder {
+	b.maxScaleFactor = int(v)
+	return b
+}
+
+// Validate the asset set configuration. The returned error is a
+// `*validation.Report` containing every error found.
 func (b *AssetBuilder) Validate() error {
+	return b.Report().Err()
+}
+
+// Report validates the asset set configuration and returns a report
+// containing every issue found. Issue paths identify the offending asset
+// definition, i.e. `assets[1].source`.
+func (b *AssetBuilder) Report() *validation.Report {
+	r := &validation.Report{}
+
 	if len(b.defs) == 0 {
-		return fmt.Errorf("No assets defined for %v", b.name)
+		r.AddError("assets", CodeNoDefinitions, "", fmt.Errorf("No assets defined for %v", b.name))
+		return r
 	}
 
-	for _, d := range b.defs {
-		if err := d.Validate(); err != nil {
-			return fmt.Errorf("Invalid asset definition: %v", err)
-		}
+	if err := b.Encoding.Validate(); err != nil {
+		r.AddError("encoding", CodeInvalidEncoding, "", err)
+	}
+
+	for idx, d := range b.defs {
+		r.Merge(validation.Index("assets", idx), b.definition(d).Report())
 	}
 
 	// Validate against each other
-	for _, d1 := range b.defs {
-		for _, d2 := range b.defs {
-			if err := d1.detectOverlap(d2); err != nil {
-				// TODO: Need a way to identify the invalid def, and bubble up
-				return fmt.Errorf("Overlapping asset definitions: %v", err)
-			}
+	for i, d1 := range b.defs {
+		for j := i + 1; j < len(b.defs); j++ {
+			d2 := b.defs[j]
+			overlaps := overlapReport(validation.Index("assets", i), &d2.Devices, &d1.Devices, &d2.Appearance, &d1.Appearance)
+			r.Merge(validation.Index("assets", j), overlaps)
 		}
 	}
 
-	return nil
+	return r
 }
 
 // Build will construct the Contents.json of the asset and validate the
@@ -99,6 +132,7 @@ func (b *AssetBuilder) Build() (*AssetOutput, error) {
 	}
 
 	output.inputs = inputs
+	output.encoding = b.Encoding
 	images := make([]AssetImage, len(inputs))
 	for idx, input := range inputs {
 		images[idx] = input.image()
@@ -113,69 +147,75 @@ func (b *AssetBuilder) buildInputs() ([]assetInput, error) {
 	inputs := []assetInput{}
 
 	for _, d := range b.defs {
-		inputs = append(inputs, d.build(b.name, gamuts)...)
+		inputs = append(inputs, b.definition(d).build(b.name, gamuts)...)
+	}
+
+	for idx := range inputs {
+		inputs[idx].Extension = b.Encoding.extension()
 	}
 
 	return inputs, nil
 }
 
-func (b *AssetBuilder) exists(path string) (bool, error) {
-	_, err := os.Stat(path)
-	if err == nil {
-		return true, nil
-	}
-	if os.IsNotExist(err) {
-		return false, nil
-	}
-	return false, err
+// definition returns a copy of the asset definition whose source inherits the
+// image set defaults, leaving the definition itself unchanged.
+func (b *AssetBuilder) definition(d *AssetDefinition) *AssetDefinition {
+	resolved := *d
+	resolved.Source = d.Source.inherit(b.interpolation, b.maxScaleFactor)
+
+	return &resolved
 }
 
 // SaveTo will save the asset to the specified path.
 func (b *AssetBuilder) SaveTo(path string, overwrite bool) error {
-	stat, err := os.Stat(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("Path does not exist: %w", err)
-		}
+	return b.SaveToFS(filesystem.OSFileSystem{}, path, overwrite)
+}
 
-		return fmt.Errorf("Failed to validate path: %w", err)
+// SaveToFS will save the asset to the specified path within the provided
+// file system. The .imageset folder is built in a temporary folder and moved
+// into place once every file has been written.
+func (b *AssetBuilder) SaveToFS(fsys filesystem.FileSystem, path string, overwrite bool) error {
+	files, err := b.files()
+	if err != nil {
+		return err
 	}
 
-	if !stat.IsDir() {
-		return fmt.Errorf("SaveTo path must be a directory")
-	}
+	return saveFolder(fsys, path, b.folder(), files, overwrite)
+}
 
-	// Create .appiconset folder
-	folder := filepath.Join(path, fmt.Sprintf("%v.imageset", b.name))
-	if exists, err := b.exists(folder); exists && err == nil && overwrite {
-		if err := os.RemoveAll(folder); err != nil {
-			return fmt.Errorf("Failed to remove .imageset folder: %w", err)
-		}
+// DryRun will build the asset and return the changes that saving it to the
+// specified path within the provided file system would make, without writing
+// anything.
+func (b *AssetBuilder) DryRun(fsys filesystem.FileSystem, path string) ([]FileChange, error) {
+	files, err := b.files()
+	if err != nil {
+		return nil, err
 	}
 
-	if err = os.Mkdir(folder, os.ModePerm); err != nil {
-		return fmt.Errorf("Failed to create imageset folder: %w", err)
-	}
+	return planFolder(fsys, path, b.folder(), files)
+}
+
+func (b *AssetBuilder) folder() string {
+	return fmt.Sprintf("%v.imageset", b.name)
+}
 
+func (b *AssetBuilder) files() (map[string][]byte, error) {
 	output, err := b.Build()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data, err := json.Marshal(output)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal Contents.json: %w", err)
+		return nil, fmt.Errorf("Failed to marshal Contents.json: %w", err)
 	}
 
-	err = ioutil.WriteFile(filepath.Join(folder, "Contents.json"), data, os.ModePerm)
+	files, err := output.renderImages()
 	if err != nil {
-		return fmt.Errorf("Failed to write Contents.json to file: %w", err)
+		return nil, fmt.Errorf("Failed to write images to file: %w", err)
 	}
 
-	err = output.WriteImages(folder)
-	if err != nil {
-		return fmt.Errorf("Failed to write images to file: %w", err)
-	}
+	files["Contents.json"] = data
 
-	return nil
+	return files, nil
 }
@@ -1,9 +1,11 @@
 package xcassets
 
 import (
+	"errors"
 	"fmt"
 	"math"
-	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
 )
 
 // AssetImage is used to construct the JSON in Contents.json `images`.
@@ -18,13 +20,14 @@ type AssetImage struct {
 }
 
 type assetInput struct {
-	Width    int
-	Height   int
-	Idiom    string
-	Filename string
-	Scale    float64
-	Role     string
-	Subtype  string
+	Width     int
+	Height    int
+	Idiom     string
+	Filename  string
+	Extension string
+	Scale     float64
+	Role      string
+	Subtype   string
 
 	Appearances  []appearance
 	DisplayGamut string
@@ -35,7 +38,7 @@ type assetInput struct {
 func (i *assetInput) image() AssetImage {
 	if delta := math.Floor(i.Scale) - i.Scale; delta != 0 {
 		return AssetImage{
-			Filename:    i.Filename + ".png",
+			Filename:    i.Filename + i.Extension,
 			Idiom:       i.Idiom,
 			Scale:       fmt.Sprintf("%.1fx", i.Scale),
 			Subtype:     i.Subtype,
@@ -44,7 +47,7 @@ func (i *assetInput) image() AssetImage {
 	}
 
 	return AssetImage{
-		Filename:    i.Filename + ".png",
+		Filename:    i.Filename + i.Extension,
 		Idiom:       i.Idiom,
 		Scale:       fmt.Sprintf("%0.fx", i.Scale),
 		Subtype:     i.Subtype,
@@ -61,38 +64,28 @@ type AssetDefinition struct {
 	Source AssetSource
 }
 
-// Validate will ensure that you have a valid `ColorDefinition`, returning
+// Validate will ensure that you have a valid `AssetDefinition`, returning
 // any errors.
 func (d *AssetDefinition) Validate() error {
-	if err := d.Devices.Validate(); err != nil {
-		return err
-	}
-
-	if d.Source.Empty() {
-		return fmt.Errorf("No asset present - please specify an asset source")
-	}
-
-	if err := d.Source.Validate(); err != nil {
-		return err
-	}
-
-	return nil
+	return d.Report().Err()
 }
 
-func (d *AssetDefinition) detectOverlap(d2 *AssetDefinition) error {
-	if d == d2 {
-		return nil
-	}
+// Report will validate the `AssetDefinition` and return a report containing
+// every issue found.
+func (d *AssetDefinition) Report() *validation.Report {
+	r := &validation.Report{}
 
-	if intersection := d.Devices.intersects(&d2.Devices); len(intersection) > 0 {
-		return fmt.Errorf("Devices between color definitions overlap (%v) - they must be unique", strings.Join(intersection, ","))
+	if err := d.Devices.Validate(); err != nil {
+		r.AddError("devices", CodeInvalidDevices, "", err)
 	}
 
-	if intersection := d.Appearance.intersects(&d2.Appearance); len(intersection) > 0 {
-		return fmt.Errorf("Appearances overlap (%v) - they must be unique", strings.Join(intersection, ","))
+	if d.Source.Empty() {
+		r.AddError("source", CodeInvalidSource, "", errors.New("No asset present - please specify an asset source"))
+	} else if err := d.Source.Validate(); err != nil {
+		r.AddError("source", CodeInvalidSource, "", err)
 	}
 
-	return nil
+	return r
 }
 
 type assetImageInput struct {
@@ -150,13 +143,9 @@ func (d *AssetDefinition) buildInputs(name, device string, appearance []appearan
 	switch device {
 	case "universal":
 		for _, c := range containers {
-			variations := []assetInput{
-				d.buildInput(c, name, device, 1),
-				d.buildInput(c, name, device, 2),
-				d.buildInput(c, name, device, 3),
+			for scale := 1; scale <= d.Source.scaleFactor(); scale++ {
+				final = append(final, d.buildInput(c, name, device, float64(scale)))
 			}
-
-			final = append(final, variations...)
 		}
 		// TODO: other device types
 	}
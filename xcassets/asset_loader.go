@@ -3,6 +3,7 @@ package xcassets
 import (
 	"fmt"
 	"image"
+	imagecolor "image/color"
 	_ "image/jpeg" // support for JPG images
 	_ "image/png"  // support for PNG images
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"path"
 	"path/filepath"
+	"strings"
 
 	_ "golang.org/x/image/bmp" // support for BMP images
 
@@ -24,15 +26,26 @@ type assetLoader struct {
 }
 
 func (l *assetLoader) Key(source AssetSource) string {
+	key := ""
+
 	if path := source.file; path != "" {
-		return path
+		key = path
+	} else if url := source.url; url != "" {
+		key = url
+	} else if len(source.fill) > 0 {
+		colors := make([]string, len(source.fill))
+		for idx, d := range source.fill {
+			colors[idx] = colorKey(d.nrgba())
+		}
+
+		key = fmt.Sprintf("fill(%v,%v)", strings.Join(colors, ","), source.horizontal)
 	}
 
-	if url := source.url; url != "" {
-		return url
+	if len(source.transforms) > 0 {
+		key += "|" + transformKey(source.transforms)
 	}
 
-	return ""
+	return key
 }
 
 func (l *assetLoader) Load(source AssetSource) (image.Image, error) {
@@ -46,6 +59,10 @@ func (l *assetLoader) Load(source AssetSource) (image.Image, error) {
 		return img, err
 	}
 
+	if len(source.fill) > 0 {
+		return l.loadImageFromColors(source)
+	}
+
 	return nil, fmt.Errorf("No image source specified")
 }
 
@@ -58,9 +75,52 @@ func (l *assetLoader) Validate() error {
 		return l.validateURL(url)
 	}
 
+	if len(l.source.fill) > 0 {
+		return l.source.validateFill()
+	}
+
 	return nil
 }
 
+func (l *assetLoader) loadImageFromColors(source AssetSource) (image.Image, error) {
+	if err := source.validateFill(); err != nil {
+		return nil, err
+	}
+
+	width, height := source.fillSize()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	from := source.fill[0].nrgba()
+	to := from
+	if len(source.fill) > 1 {
+		to = source.fill[len(source.fill)-1].nrgba()
+	}
+
+	lerp := func(a, b uint8, t float64) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t := 0.0
+			if source.horizontal && width > 1 {
+				t = float64(x) / float64(width-1)
+			} else if !source.horizontal && height > 1 {
+				t = float64(y) / float64(height-1)
+			}
+
+			img.SetNRGBA(x, y, imagecolor.NRGBA{
+				R: lerp(from.R, to.R, t),
+				G: lerp(from.G, to.G, t),
+				B: lerp(from.B, to.B, t),
+				A: lerp(from.A, to.A, t),
+			})
+		}
+	}
+
+	return img, nil
+}
+
 func (l *assetLoader) loadImageFromFile(path string) (image.Image, error) {
 	file, err := os.Open(path)
 	if err != nil {
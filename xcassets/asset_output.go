@@ -1,12 +1,11 @@
 package xcassets
 
 import (
+	"bytes"
 	"fmt"
 	"image"
-	"image/png"
-	"os"
-	"path/filepath"
 
+	"github.com/illyabusigin/apptools/filesystem"
 	"github.com/nfnt/resize"
 )
 
@@ -16,14 +15,31 @@ type AssetOutput struct {
 	Info       info             `json:"info"`
 	Properties *AssetProperties `json:"properties,omitempty"`
 
-	inputs []assetInput
+	inputs   []assetInput
+	encoding ImageEncoding
 }
 
 // WriteImages will write the file in `Images` to the specified path.
 func (o *AssetOutput) WriteImages(path string) error {
+	return o.WriteImagesFS(filesystem.OSFileSystem{}, path)
+}
+
+// WriteImagesFS will write the file in `Images` to the specified path within the
+// provided file system.
+func (o *AssetOutput) WriteImagesFS(fsys filesystem.FileSystem, path string) error {
+	images, err := o.renderImages()
+	if err != nil {
+		return err
+	}
+
+	return writeFiles(fsys, path, images)
+}
 
+// renderImages renders every image in memory, keyed by file name.
+func (o *AssetOutput) renderImages() (map[string][]byte, error) {
 	loader := assetLoader{}
 	cache := map[string]image.Image{}
+	images := map[string][]byte{}
 
 	for _, input := range o.inputs {
 		img, ok := cache[loader.Key(input.Source)]
@@ -31,26 +47,26 @@ func (o *AssetOutput) WriteImages(path string) error {
 		if !ok {
 			img, err = loader.Load(input.Source)
 			if err != nil {
-				return fmt.Errorf("Failed to load image from source %v, error: %w", input.Source, err)
+				return nil, fmt.Errorf("Failed to load image from source %v, error: %w", input.Source, err)
+			}
+
+			img, err = input.Source.transform(img)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to transform image from source %v, error: %w", input.Source, err)
 			}
 
 			cache[loader.Key(input.Source)] = img
 		}
 
-		m := resize.Resize(uint(input.Width), uint(input.Height), img, resize.Lanczos3)
-		fileName := input.Filename + ".png"
-		dest := filepath.Join(path, fileName)
+		m := resize.Resize(uint(input.Width), uint(input.Height), img, input.Source.interpolationFunction())
 
-		out, err := os.Create(dest)
-		if err != nil {
-			return fmt.Errorf("Failed to create image file, error: %w", err)
+		buf := bytes.Buffer{}
+		if err := o.encoding.encode(&buf, m); err != nil {
+			return nil, fmt.Errorf("Failed to encode image file, error: %w", err)
 		}
-		defer out.Close()
 
-		if err := png.Encode(out, m); err != nil {
-			return fmt.Errorf("Failed to write image file, error: %w", err)
-		}
+		images[input.Filename+input.Extension] = buf.Bytes()
 	}
 
-	return nil
+	return images, nil
 }
@@ -3,18 +3,55 @@ package xcassets
 import (
 	"fmt"
 	"image"
+	imagecolor "image/color"
 	"math"
+
+	"github.com/illyabusigin/apptools/validation"
+	"github.com/nfnt/resize"
+)
+
+const (
+	// DefaultInterpolation is the resampling filter used when scaling source
+	// images if no interpolation function has been specified.
+	DefaultInterpolation = resize.Lanczos3
+
+	// DefaultMaxScaleFactor is the largest scale factor generated for an image
+	// set if no maximum scale factor has been specified.
+	DefaultMaxScaleFactor = 3
 )
 
 // AssetSource allows you to define a source for your asset. Asset sources can
-// be remote or local.
+// be remote, local or generated from colors.
 type AssetSource struct {
 	url          string
 	file         string
+	fill         []*ColorDefinition
+	horizontal   bool
 	validated    bool
 	minDimension int
 
 	desiredWidth, desiredHeight int
+
+	interpolation  *resize.InterpolationFunction
+	maxScaleFactor int
+
+	transforms []ImageTransform
+}
+
+func (s *AssetSource) interpolationFunction() resize.InterpolationFunction {
+	if s.interpolation == nil {
+		return DefaultInterpolation
+	}
+
+	return *s.interpolation
+}
+
+func (s *AssetSource) scaleFactor() int {
+	if s.maxScaleFactor == 0 {
+		return DefaultMaxScaleFactor
+	}
+
+	return s.maxScaleFactor
 }
 
 func (s *AssetSource) hasDimensions() bool {
@@ -37,13 +74,17 @@ func (s *AssetSource) Validate() error {
 	}
 
 	if s.Empty() {
-		return fmt.Errorf("No URL or file location specified for asset source")
+		return fmt.Errorf("No URL, file location or color specified for asset source")
 	}
 
 	if s.minDimension <= 0 && !s.hasDimensions() {
 		return fmt.Errorf("Minimum asset dimension invalid or not specified (%v)", s.minDimension)
 	}
 
+	if s.maxScaleFactor > DefaultMaxScaleFactor {
+		return fmt.Errorf("Maximum scale factor is invalid (%v), must be between 1 and %v", s.maxScaleFactor, DefaultMaxScaleFactor)
+	}
+
 	loader := assetLoader{
 		source: *s,
 	}
@@ -57,7 +98,38 @@ func (s *AssetSource) Validate() error {
 	return nil
 }
 
+func (s *AssetSource) transformBounds(r image.Rectangle) (image.Rectangle, error) {
+	for _, t := range s.transforms {
+		var err error
+		if r, err = t.Bounds(r); err != nil {
+			return r, fmt.Errorf("Invalid transform %v: %w", t.Key(), err)
+		}
+	}
+
+	return r, nil
+}
+
+func (s *AssetSource) transform(img image.Image) (image.Image, error) {
+	for _, t := range s.transforms {
+		var err error
+		if img, err = t.Transform(img); err != nil {
+			return nil, fmt.Errorf("Failed to apply transform %v: %w", t.Key(), err)
+		}
+	}
+
+	return img, nil
+}
+
 func (s *AssetSource) validateImage(image image.Config, fileName string) error {
+	if len(s.transforms) > 0 {
+		bounds, err := s.transformBounds(imageRect(image.Width, image.Height))
+		if err != nil {
+			return err
+		}
+
+		image.Width, image.Height = bounds.Dx(), bounds.Dy()
+	}
+
 	if s.hasDimensions() {
 		desiredAspectRatio := float64(s.desiredWidth) / float64(s.desiredHeight)
 		aspectRatio := float64(image.Width) / float64(image.Height)
@@ -69,10 +141,10 @@ func (s *AssetSource) validateImage(image image.Config, fileName string) error {
 				image.Width, image.Height)
 		}
 
-		scaleFactor := 3 // TODO: Expose this at some point to make it configurable
+		scaleFactor := s.scaleFactor()
 		if image.Width < s.desiredWidth*scaleFactor || image.Height < s.desiredHeight*scaleFactor {
-			return fmt.Errorf("%v dimensions (%vx%v) have dimensions less than the minimum  required for scaling (%vx%v)",
-				fileName, image.Width, image.Height, s.desiredWidth, s.desiredHeight)
+			return fmt.Errorf("%v dimensions (%vx%v) have dimensions less than the minimum required for scaling to @%vx (%vx%v)",
+				fileName, image.Width, image.Height, scaleFactor, s.desiredWidth*scaleFactor, s.desiredHeight*scaleFactor)
 		}
 	} else {
 		if image.Width < s.minDimension || image.Height < s.minDimension {
@@ -88,6 +160,7 @@ func (s *AssetSource) validateImage(image image.Config, fileName string) error {
 // validation phase.
 func (s *AssetSource) URL(url string) {
 	s.url = url
+	s.fill = nil
 	s.validated = false
 }
 
@@ -95,20 +168,86 @@ func (s *AssetSource) URL(url string) {
 // during the validation phase.
 func (s *AssetSource) File(path string) {
 	s.file = path
+	s.fill = nil
 	s.validated = false
 }
 
+// Color specifies a solid color fill for your asset instead of an image. The
+// image is rendered at each scale using the dimensions specified with `Size`,
+// or `MinDimension` for icons. Certain properties are set by default and can
+// be overridden, specifically:
+//  d.ColorSpace.SRGB()
+//  d.Alpha(1)
+func (s *AssetSource) Color(f func(d *ColorDefinition)) {
+	s.setFill(false, f)
+}
+
+// Gradient specifies a top-to-bottom linear gradient between two colors for
+// your asset instead of an image. See `Color` for the defaults applied to each
+// color definition.
+func (s *AssetSource) Gradient(from, to func(d *ColorDefinition)) {
+	s.setFill(false, from, to)
+}
+
+// HorizontalGradient specifies a left-to-right linear gradient between two
+// colors for your asset instead of an image. See `Color` for the defaults
+// applied to each color definition.
+func (s *AssetSource) HorizontalGradient(from, to func(d *ColorDefinition)) {
+	s.setFill(true, from, to)
+}
+
+func (s *AssetSource) setFill(horizontal bool, fs ...func(d *ColorDefinition)) {
+	s.url = ""
+	s.file = ""
+	s.fill = make([]*ColorDefinition, len(fs))
+	s.horizontal = horizontal
+	s.validated = false
+
+	for idx, f := range fs {
+		d := &ColorDefinition{}
+		d.ColorSpace.SRGB()
+		d.Alpha(1)
+		f(d)
+
+		s.fill[idx] = d
+	}
+}
+
+func (s *AssetSource) validateFill() error {
+	r := &validation.Report{}
+	for idx, d := range s.fill {
+		colors := &validation.Report{}
+		d.validateColor(colors)
+		r.Merge(validation.Index("fill", idx), colors)
+	}
+
+	return r.Err()
+}
+
+func (s *AssetSource) fillSize() (int, int) {
+	if s.hasDimensions() {
+		return s.desiredWidth * s.scaleFactor(), s.desiredHeight * s.scaleFactor()
+	}
+
+	return s.minDimension, s.minDimension
+}
+
 // Empty returns a boolean value indicating whether or not the asset source
 // is empty.
 func (s AssetSource) Empty() bool {
-	return s.url == "" && s.file == ""
+	return s.url == "" && s.file == "" && len(s.fill) == 0
 }
 
 // Apply values from the provided asset source to the destination asset source.
 func (s *AssetSource) Apply(from AssetSource) {
 	s.file = from.file
 	s.url = from.url
+	s.fill = from.fill
+	s.horizontal = from.horizontal
 	s.validated = from.validated
+	s.interpolation = from.interpolation
+	s.maxScaleFactor = from.maxScaleFactor
+	s.transforms = append([]ImageTransform(nil), from.transforms...)
 }
 
 // MinDimension specifies the minimum dimension for the icon image.
@@ -124,3 +263,75 @@ func (s *AssetSource) Size(width, height uint) {
 	s.desiredHeight = int(height)
 	s.validated = false
 }
+
+// Interpolation specifies the resampling filter used when scaling the source
+// image to each output size. Defaults to `resize.Lanczos3`; use
+// `resize.NearestNeighbor` for pixel art.
+func (s *AssetSource) Interpolation(f resize.InterpolationFunction) {
+	s.interpolation = &f
+}
+
+// MaxScaleFactor specifies the largest scale factor (1, 2 or 3) generated for
+// the asset. The source image must be at least this many times larger than
+// the 1x size specified with `Size`. Defaults to 3.
+func (s *AssetSource) MaxScaleFactor(v uint) {
+	s.maxScaleFactor = int(v)
+	s.validated = false
+}
+
+func imageRect(width, height int) image.Rectangle {
+	return image.Rect(0, 0, width, height)
+}
+
+// inherit returns a copy of the source using the specified defaults for the
+// interpolation function and maximum scale factor it does not specify itself.
+func (s AssetSource) inherit(interpolation *resize.InterpolationFunction, maxScaleFactor int) AssetSource {
+	if s.interpolation == nil {
+		s.interpolation = interpolation
+	}
+
+	if s.maxScaleFactor == 0 && maxScaleFactor != 0 {
+		s.maxScaleFactor = maxScaleFactor
+		s.validated = false
+	}
+
+	return s
+}
+
+// Transform appends a custom transform to the source's transform pipeline.
+// Transforms run in the order they are added, after the source image is
+// loaded and before it is resized.
+func (s *AssetSource) Transform(t ImageTransform) {
+	s.transforms = append(s.transforms, t)
+	s.validated = false
+}
+
+// Pad surrounds the source image with transparent padding. The padding on
+// each side is the provided ratio of the image's largest dimension, i.e.
+// `Pad(0.1)` adds 10% on every side.
+func (s *AssetSource) Pad(ratio float64) {
+	s.Transform(padTransform{ratio: ratio})
+}
+
+// Background fills any transparent areas of the source image with a solid
+// color. App Store marketing icons must not contain transparency.
+func (s *AssetSource) Background(c imagecolor.Color) {
+	s.Transform(backgroundTransform{color: c})
+}
+
+// Tint blends the source image towards the provided color while preserving
+// its transparency. The amount must be between 0 (no tint) and 1 (solid color).
+func (s *AssetSource) Tint(c imagecolor.Color, amount float64) {
+	s.Transform(tintTransform{color: c, amount: amount})
+}
+
+// Crop crops the source image to the provided rectangle, relative to the
+// top-left corner of the image.
+func (s *AssetSource) Crop(r image.Rectangle) {
+	s.Transform(cropTransform{rect: r})
+}
+
+// CropSquare crops the source image to the largest centered square.
+func (s *AssetSource) CropSquare() {
+	s.Transform(cropTransform{square: true})
+}
@@ -0,0 +1,185 @@
+package xcassets
+
+import (
+	"image"
+	"os"
+	"testing"
+
+	"github.com/nfnt/resize"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAssetSource_validateImage(t *testing.T) {
+	type fields struct {
+		source func() *AssetSource
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		image   image.Config
+		wantErr bool
+	}{
+		{
+			name: "Image large enough for the default scale factor should pass",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Size(100, 100)
+					return s
+				},
+			},
+			image:   image.Config{Width: 300, Height: 300},
+			wantErr: false,
+		},
+		{
+			name: "Image too small for the default scale factor should fail",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Size(100, 100)
+					return s
+				},
+			},
+			image:   image.Config{Width: 200, Height: 200},
+			wantErr: true,
+		},
+		{
+			name: "Image large enough for a 2x maximum scale factor should pass",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Size(100, 100)
+					s.MaxScaleFactor(2)
+					return s
+				},
+			},
+			image:   image.Config{Width: 200, Height: 200},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.fields.source()
+			err := s.validateImage(tt.image, "Icon.png")
+
+			if tt.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAssetSource_MaxScaleFactor(t *testing.T) {
+	s := AssetSource{}
+	s.File("./testdata/Icon.png")
+	s.Size(256, 256)
+	s.MaxScaleFactor(4)
+
+	assert.NotNil(t, s.Validate(), "Scale factors above 3 should fail validation")
+}
+
+func TestAssetSource_Interpolation(t *testing.T) {
+	s := AssetSource{}
+	assert.Equal(t, DefaultInterpolation, s.interpolationFunction())
+
+	s.Interpolation(resize.NearestNeighbor)
+	assert.Equal(t, resize.NearestNeighbor, s.interpolationFunction())
+
+	s2 := AssetSource{}
+	s2.Apply(s)
+	assert.Equal(t, resize.NearestNeighbor, s2.interpolationFunction())
+}
+
+func TestAssetBuilder_MaxScaleFactor(t *testing.T) {
+	builder := Asset("Logo", func(b *AssetBuilder) {
+		b.MaxScaleFactor(2)
+		b.Interpolation(resize.NearestNeighbor)
+		b.Asset(func(d *AssetDefinition) {
+			d.Devices.Universal()
+			d.Source.File("./testdata/Icon.png")
+			d.Source.Size(1200, 1200)
+		})
+	})
+
+	output, err := builder.Build()
+	assert.Nil(t, err)
+	assert.Len(t, output.Images, 2)
+	assert.Equal(t, "2x", output.Images[1].Scale)
+	assert.Equal(t, resize.NearestNeighbor, output.inputs[0].Source.interpolationFunction())
+
+	assert.Equal(t, 0, builder.defs[0].Source.maxScaleFactor, "Validating should not modify the asset source")
+
+	inputs, err := builder.buildInputs()
+	assert.Nil(t, err)
+	assert.Len(t, inputs, 2, "Builder defaults should apply without validating")
+}
+
+func TestAssetSource_Color(t *testing.T) {
+	s := AssetSource{}
+	s.Size(10, 20)
+	s.Color(func(d *ColorDefinition) {
+		d.Hex("#ff0000")
+	})
+
+	assert.False(t, s.Empty())
+	assert.Nil(t, s.Validate())
+
+	loader := assetLoader{}
+	img, err := loader.Load(s)
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 30, 60), img.Bounds())
+
+	r, g, b, a := img.At(5, 5).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0, 0xffff}, []uint32{r, g, b, a})
+
+	s.File("./testdata/Icon.png")
+	assert.Empty(t, s.fill, "Specifying a file should replace the color fill")
+}
+
+func TestAssetSource_Gradient(t *testing.T) {
+	s := AssetSource{}
+	s.MinDimension(64)
+	s.HorizontalGradient(func(d *ColorDefinition) {
+		d.RGB(0, 0, 0)
+	}, func(d *ColorDefinition) {
+		d.RGB(255, 255, 255)
+	})
+
+	assert.Nil(t, s.Validate())
+
+	loader := assetLoader{}
+	img, err := loader.Load(s)
+	assert.Nil(t, err)
+
+	left, _, _, _ := img.At(0, 0).RGBA()
+	right, _, _, _ := img.At(63, 0).RGBA()
+	assert.Equal(t, uint32(0), left)
+	assert.Equal(t, uint32(0xffff), right)
+
+	s.Gradient(func(d *ColorDefinition) {}, func(d *ColorDefinition) {
+		d.RGB(255, 255, 255)
+	})
+	assert.NotNil(t, s.Validate(), "Gradient colors without a color should fail validation")
+}
+
+func TestAsset_ColorSource(t *testing.T) {
+	builder := Asset("Background", func(b *AssetBuilder) {
+		b.Asset(func(d *AssetDefinition) {
+			d.Devices.Universal()
+			d.Source.Size(32, 64)
+			d.Source.Gradient(func(d *ColorDefinition) {
+				d.Hex("#262d44")
+			}, func(d *ColorDefinition) {
+				d.Hex("#000000")
+			})
+		})
+	})
+
+	assert.Nil(t, builder.Validate())
+
+	err := builder.SaveTo("./_test/", true)
+	assert.Nil(t, err)
+	assert.Nil(t, os.RemoveAll("./_test/Background.imageset"))
+}
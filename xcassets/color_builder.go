@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
 )
 
 // Color creates a named color type with the specified name, returning a
@@ -47,29 +49,37 @@ func (b *ColorBuilder) Color(f func(d *ColorDefinition)) *ColorBuilder {
 	return b
 }
 
-// Validate the color set configuration.
+// Validate the color set configuration. The returned error is a
+// `*validation.Report` containing every error found.
 func (b *ColorBuilder) Validate() error {
+	return b.Report().Err()
+}
+
+// Report validates the color set configuration and returns a report
+// containing every issue found. Issue paths identify the offending color
+// definition, i.e. `colors[2].appearance`.
+func (b *ColorBuilder) Report() *validation.Report {
+	r := &validation.Report{}
+
 	if len(b.defs) == 0 {
-		return fmt.Errorf("No colors defined for %v", b.name)
+		r.AddError("colors", CodeNoDefinitions, "", fmt.Errorf("No colors defined for %v", b.name))
+		return r
 	}
 
-	for _, d := range b.defs {
-		if err := d.Validate(); err != nil {
-			return fmt.Errorf("Invalid color definition: %v", err)
-		}
+	for idx, d := range b.defs {
+		r.Merge(validation.Index("colors", idx), d.Report())
 	}
 
 	// Validate against each other
-	for _, d1 := range b.defs {
-		for _, d2 := range b.defs {
-			if err := d1.detectOverlap(d2); err != nil {
-				// TODO: Need a way to identify the invalid def, and bubble up
-				return fmt.Errorf("Overlapping color definitions: %v", err)
-			}
+	for i, d1 := range b.defs {
+		for j := i + 1; j < len(b.defs); j++ {
+			d2 := b.defs[j]
+			overlaps := overlapReport(validation.Index("colors", i), &d2.Devices, &d1.Devices, &d2.Appearance, &d1.Appearance)
+			r.Merge(validation.Index("colors", j), overlaps)
 		}
 	}
 
-	return nil
+	return r
 }
 
 // Build will construct the Contents.json of the color.
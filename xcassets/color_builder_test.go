@@ -176,3 +176,40 @@ func TestColorBuilder_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestColorBuilder_Report(t *testing.T) {
+	b := Color("test", func(b *ColorBuilder) {
+		b.Color(func(d *ColorDefinition) {
+			d.Hex("#ff0000")
+			d.Devices.Universal()
+		})
+		b.Color(func(d *ColorDefinition) {
+			d.RGB(300, 0, 0)
+			d.Alpha(2)
+		})
+		b.Color(func(d *ColorDefinition) {
+			d.Hex("#00ff00")
+			d.Devices.Universal()
+		})
+	})
+
+	report := b.Report()
+	paths := []string{}
+	codes := []string{}
+	for _, issue := range report.Errors() {
+		paths = append(paths, issue.Path)
+		codes = append(codes, issue.Code)
+	}
+
+	assert.Equal(t, []string{
+		"colors[1].color",
+		"colors[1].alpha",
+		"colors[1].devices",
+		"colors[1].appearance",
+		"colors[2].devices",
+		"colors[2].appearance",
+		"colors[2].appearance",
+	}, paths)
+	assert.Equal(t, CodeOverlappingDevices, codes[4])
+	assert.Contains(t, report.Errors()[4].Message, "colors[0]")
+}
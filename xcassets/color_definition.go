@@ -1,9 +1,12 @@
 package xcassets
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	imagecolor "image/color"
+	"math"
 
+	"github.com/illyabusigin/apptools/validation"
 	"gopkg.in/go-playground/colors.v1"
 )
 
@@ -28,19 +31,21 @@ type ColorDefinition struct {
 // Validate will ensure that you have a valid `ColorDefinition`, returning
 // any errors.
 func (d *ColorDefinition) Validate() error {
-	if !d.colorPresent() {
-		return fmt.Errorf("No color present - please specify a color")
-	}
+	return d.Report().Err()
+}
 
-	if err := d.validateColor(); err != nil {
-		return err
-	}
+// Report will validate the `ColorDefinition` and return a report containing
+// every issue found.
+func (d *ColorDefinition) Report() *validation.Report {
+	r := &validation.Report{}
+
+	d.validateColor(r)
 
 	if err := d.Devices.Validate(); err != nil {
-		return err
+		r.AddError("devices", CodeInvalidDevices, "", err)
 	}
 
-	return nil
+	return r
 }
 
 func (d *ColorDefinition) color(gamuts []string) color {
@@ -75,52 +80,76 @@ func (d *ColorDefinition) color(gamuts []string) color {
 	return c
 }
 
-func (d *ColorDefinition) detectOverlap(d2 *ColorDefinition) error {
-	if d == d2 {
-		return nil
+// nrgba converts the color definition to an 8-bit color for rendering. The
+// components are used as-is, without color space conversion.
+func (d *ColorDefinition) nrgba() imagecolor.NRGBA {
+	component := func(v float64) uint8 {
+		return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
 	}
 
-	if intersection := d.Devices.intersects(&d2.Devices); len(intersection) > 0 {
-		return fmt.Errorf("Devices between color definitions overlap (%v) - they must be unique", strings.Join(intersection, ","))
+	c := imagecolor.NRGBA{
+		A: component(d.alpha),
 	}
 
-	if intersection := d.Appearance.intersects(&d2.Appearance); len(intersection) > 0 {
-		return fmt.Errorf("Appearances overlap (%v) - they must be unique", strings.Join(intersection, ","))
+	switch {
+	case d.hex != "":
+		if hex, err := colors.ParseHEX(d.hex); err == nil {
+			v := hex.ToRGB()
+			c.R, c.G, c.B = v.R, v.G, v.B
+		}
+	case d.eighBit:
+		c.R, c.G, c.B = uint8(d.r), uint8(d.g), uint8(d.b)
+	case d.floatingPoint:
+		c.R, c.G, c.B = component(d.r), component(d.g), component(d.b)
+	case d.ColorSpace.grayscale:
+		w := component(d.white)
+		c.R, c.G, c.B = w, w, w
 	}
 
-	return nil
+	return c
 }
 
-func (d *ColorDefinition) validateColor() error {
+func (d *ColorDefinition) detectOverlap(d2 *ColorDefinition) error {
+	if d == d2 {
+		return nil
+	}
+
+	return overlapReport("", &d.Devices, &d2.Devices, &d.Appearance, &d2.Appearance).Err()
+}
+
+func (d *ColorDefinition) validateColor(r *validation.Report) {
+	if !d.colorPresent() {
+		r.AddError("color", CodeInvalidColor, "", errors.New("No color present - please specify a color"))
+		return
+	}
+
 	if d.hex != "" {
 		if err := d.Hex(d.hex); err != nil {
-			return err
+			r.AddError("color", CodeInvalidColor, "", err)
 		}
 	}
 
 	if d.eighBit {
 		if err := d.RGB(int(d.r), int(d.g), int(d.b)); err != nil {
-			return err
+			r.AddError("color", CodeInvalidColor, "", err)
 		}
 	}
 
 	if d.floatingPoint {
 		if err := d.RGBFloat(d.r, d.g, d.b); err != nil {
-			return err
+			r.AddError("color", CodeInvalidColor, "", err)
 		}
 	}
 
 	if err := d.Alpha(d.alpha); err != nil {
-		return err
+		r.AddError("alpha", CodeInvalidColor, "", err)
 	}
 
 	if d.ColorSpace.grayscale {
 		if err := d.White(d.white); err != nil {
-			return err
+			r.AddError("white", CodeInvalidColor, "", err)
 		}
 	}
-
-	return nil
 }
 
 func (d *ColorDefinition) colorPresent() bool {
@@ -2,10 +2,11 @@ package xcassets
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	"github.com/illyabusigin/apptools/validation"
 )
 
 const MinDimension = 1024
@@ -27,7 +28,8 @@ func AppIcon(name string, f func(b *AppIconBuilder)) *AppIconBuilder {
 }
 
 type AppIconBuilder struct {
-	Name string
+	Name     string
+	Encoding ImageEncoding
 	AssetSource
 	iPhone   AppIconPhone
 	iPad     AppIconTablet
@@ -37,39 +39,49 @@ type AppIconBuilder struct {
 	appStore AppIconAppStore
 }
 
+// Validate will validate the app icon and every enabled idiom. The returned
+// error is a `*validation.Report` containing every error found.
 func (b *AppIconBuilder) Validate() error {
-	if !b.AssetSource.Empty() {
-		if err := b.AssetSource.Validate(); err != nil {
-			return fmt.Errorf("Source is invalid: %w", err)
-		}
-	}
-
-	// Validate each idiom
-	if err := b.iPhone.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate iphone idiom: %w", err)
-	}
-
-	if err := b.iPad.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate ipad idiom: %w", err)
-	}
+	return b.Report().Err()
+}
 
-	if err := b.watch.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate watch idiom: %w", err)
-	}
+// Report will validate the app icon and every enabled idiom, returning a
+// report containing every issue found.
+func (b *AppIconBuilder) Report() *validation.Report {
+	r := &validation.Report{}
 
-	if err := b.mac.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate mac idiom: %w", err)
+	if err := b.Encoding.Validate(); err != nil {
+		r.AddError("encoding", CodeInvalidEncoding, "", err)
+	} else if !b.Encoding.isPNG() {
+		r.AddError("encoding", CodeInvalidEncoding, "", errors.New("App icons must be encoded as PNG"))
 	}
 
-	if err := b.carPlay.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate car idiom: %w", err)
+	if !b.AssetSource.Empty() {
+		if err := b.AssetSource.Validate(); err != nil {
+			r.AddError("source", CodeInvalidSource, "", err)
+		}
 	}
 
-	if err := b.appStore.Validate(b.AssetSource); err != nil {
-		return fmt.Errorf("Failed to validate ios-marketing idiom: %w", err)
+	// Validate each idiom
+	idioms := []struct {
+		path     string
+		validate func(s AssetSource) error
+	}{
+		{"phone", b.iPhone.Validate},
+		{"tablet", b.iPad.Validate},
+		{"watch", b.watch.Validate},
+		{"mac", b.mac.Validate},
+		{"carPlay", b.carPlay.Validate},
+		{"appStore", b.appStore.Validate},
+	}
+
+	for _, idiom := range idioms {
+		if err := idiom.validate(b.AssetSource); err != nil {
+			r.AddError(idiom.path, CodeInvalidSource, "", err)
+		}
 	}
 
-	return nil
+	return r
 }
 
 // Build will validate and build the app icon.
@@ -84,6 +96,7 @@ func (b *AppIconBuilder) Build() (*AppIconOuput, error) {
 			Version: 1,
 			Author:  "xcode",
 		},
+		encoding: b.Encoding,
 	}
 
 	// Build structs for json
@@ -137,72 +150,66 @@ func (b *AppIconBuilder) Build() (*AppIconOuput, error) {
 
 	output.Images = make([]AppIconImage, len(output.Inputs))
 
-	for idx, input := range output.Inputs {
-		output.Images[idx] = input.Image()
+	for idx := range output.Inputs {
+		output.Inputs[idx].Extension = b.Encoding.extension()
+		output.Images[idx] = output.Inputs[idx].Image()
 	}
 
 	return &output, nil
 }
 
-func (b *AppIconBuilder) exists(path string) (bool, error) {
-	_, err := os.Stat(path)
-	if err == nil {
-		return true, nil
-	}
-	if os.IsNotExist(err) {
-		return false, nil
-	}
-	return false, err
-}
-
 // SaveTo will save the application icon to the specified path.
 func (b *AppIconBuilder) SaveTo(path string, overwrite bool) error {
-	stat, err := os.Stat(path)
+	return b.SaveToFS(filesystem.OSFileSystem{}, path, overwrite)
+}
+
+// SaveToFS will save the application icon to the specified path within the provided
+// file system. The .appiconset folder is built in a temporary folder and moved
+// into place once every file has been written.
+func (b *AppIconBuilder) SaveToFS(fsys filesystem.FileSystem, path string, overwrite bool) error {
+	files, err := b.files()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return fmt.Errorf("Path does not exist: %w", err)
-		} else {
-			return fmt.Errorf("Failed to validate path: %w", err)
-		}
+		return err
 	}
 
-	if !stat.IsDir() {
-		return fmt.Errorf("SaveTo path must be a directory")
-	}
+	return saveFolder(fsys, path, b.folder(), files, overwrite)
+}
 
-	// Create .appiconset folder
-	folder := filepath.Join(path, fmt.Sprintf("%v.appiconset", b.Name))
-	if exists, err := b.exists(folder); exists && err == nil && overwrite {
-		if err := os.RemoveAll(folder); err != nil {
-			return fmt.Errorf("Failed to remove .appiconset folder: %w", err)
-		}
+// DryRun will build the application icon and return the changes that saving it to the
+// specified path within the provided file system would make, without writing
+// anything.
+func (b *AppIconBuilder) DryRun(fsys filesystem.FileSystem, path string) ([]FileChange, error) {
+	files, err := b.files()
+	if err != nil {
+		return nil, err
 	}
 
-	if err = os.Mkdir(folder, os.ModePerm); err != nil {
-		return fmt.Errorf("Failed to create appiconset folder: %w", err)
-	}
+	return planFolder(fsys, path, b.folder(), files)
+}
+
+func (b *AppIconBuilder) folder() string {
+	return fmt.Sprintf("%v.appiconset", b.Name)
+}
 
+func (b *AppIconBuilder) files() (map[string][]byte, error) {
 	output, err := b.Build()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	data, err := json.Marshal(output)
 	if err != nil {
-		return fmt.Errorf("Failed to marshal Contents.json: %w", err)
+		return nil, fmt.Errorf("Failed to marshal Contents.json: %w", err)
 	}
 
-	err = ioutil.WriteFile(filepath.Join(folder, "Contents.json"), data, os.ModePerm)
+	files, err := output.renderImages()
 	if err != nil {
-		return fmt.Errorf("Failed to write Contents.json to file: %w", err)
+		return nil, fmt.Errorf("Failed to write images to file: %w", err)
 	}
 
-	err = output.WriteImages(folder)
-	if err != nil {
-		return fmt.Errorf("Failed to write images to file: %w", err)
-	}
+	files["Contents.json"] = data
 
-	return nil
+	return files, nil
 }
 
 // Phone enables phone icons.
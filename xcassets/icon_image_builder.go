@@ -1,13 +1,12 @@
 package xcassets
 
 import (
+	"bytes"
 	"fmt"
 	"image"
-	"image/png"
 	"math"
-	"os"
-	"path/filepath"
 
+	"github.com/illyabusigin/apptools/filesystem"
 	"github.com/nfnt/resize"
 )
 
@@ -16,12 +15,31 @@ type AppIconOuput struct {
 
 	Images []AppIconImage `json:"images"`
 	Info   AppIconVersion `json:"info"`
+
+	encoding ImageEncoding
 }
 
+// WriteImages will write the icon images to the specified path.
 func (o *AppIconOuput) WriteImages(path string) error {
+	return o.WriteImagesFS(filesystem.OSFileSystem{}, path)
+}
+
+// WriteImagesFS will write the icon images to the specified path within the
+// provided file system.
+func (o *AppIconOuput) WriteImagesFS(fsys filesystem.FileSystem, path string) error {
+	images, err := o.renderImages()
+	if err != nil {
+		return err
+	}
+
+	return writeFiles(fsys, path, images)
+}
 
+// renderImages renders every image in memory, keyed by file name.
+func (o *AppIconOuput) renderImages() (map[string][]byte, error) {
 	loader := assetLoader{}
 	cache := map[string]image.Image{}
+	images := map[string][]byte{}
 
 	for _, input := range o.Inputs {
 		img, ok := cache[loader.Key(input.Source)]
@@ -29,38 +47,39 @@ func (o *AppIconOuput) WriteImages(path string) error {
 		if !ok {
 			img, err = loader.Load(input.Source)
 			if err != nil {
-				return fmt.Errorf("Failed to load image from source %v, error: %w", input.Source, err)
+				return nil, fmt.Errorf("Failed to load image from source %v, error: %w", input.Source, err)
+			}
+
+			img, err = input.Source.transform(img)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to transform image from source %v, error: %w", input.Source, err)
 			}
 
 			cache[loader.Key(input.Source)] = img
 		}
 
-		m := resize.Resize(uint(input.Size*float64(input.Scale)), uint(input.Size*float64(input.Scale)), img, resize.Lanczos3)
-		fileName := input.Filename + ".png"
-		dest := filepath.Join(path, fileName)
+		m := resize.Resize(uint(input.Size*float64(input.Scale)), uint(input.Size*float64(input.Scale)), img, input.Source.interpolationFunction())
 
-		out, err := os.Create(dest)
-		if err != nil {
-			return fmt.Errorf("Failed to create image file, error: %w", err)
+		buf := bytes.Buffer{}
+		if err := o.encoding.encode(&buf, m); err != nil {
+			return nil, fmt.Errorf("Failed to encode image file, error: %w", err)
 		}
-		defer out.Close()
 
-		if err := png.Encode(out, m); err != nil {
-			return fmt.Errorf("Failed to write image file, error: %w", err)
-		}
+		images[input.Filename+input.Extension] = buf.Bytes()
 	}
 
-	return nil
+	return images, nil
 }
 
 type AppIconImageInput struct {
-	Size     float64
-	Idiom    string
-	Filename string
-	Scale    int
-	Role     string
-	Subtype  string
-	Source   AssetSource
+	Size      float64
+	Idiom     string
+	Filename  string
+	Extension string
+	Scale     int
+	Role      string
+	Subtype   string
+	Source    AssetSource
 }
 
 func (i *AppIconImageInput) Image() AppIconImage {
@@ -68,7 +87,7 @@ func (i *AppIconImageInput) Image() AppIconImage {
 		return AppIconImage{
 			Size:     fmt.Sprintf("%.1fx%.1f", i.Size, i.Size),
 			Idiom:    i.Idiom,
-			Filename: i.Filename,
+			Filename: i.Filename + i.Extension,
 			Scale:    fmt.Sprintf("%dx", i.Scale),
 			Subtype:  i.Subtype,
 		}
@@ -76,7 +95,7 @@ func (i *AppIconImageInput) Image() AppIconImage {
 		return AppIconImage{
 			Size:     fmt.Sprintf("%.0fx%.0f", i.Size, i.Size),
 			Idiom:    i.Idiom,
-			Filename: i.Filename,
+			Filename: i.Filename + i.Extension,
 			Scale:    fmt.Sprintf("%dx", i.Scale),
 			Subtype:  i.Subtype,
 		}
@@ -0,0 +1,237 @@
+package xcassets
+
+import (
+	"fmt"
+	"image"
+	imagecolor "image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sort"
+)
+
+const (
+	encodingPNG  = "png"
+	encodingJPEG = "jpeg"
+)
+
+// ImageEncoding specifies how generated images are encoded. If no encoding is
+// specified images are written as PNG with the default compression level.
+// Encoded images never include metadata from the source image.
+type ImageEncoding struct {
+	format      string
+	compression png.CompressionLevel
+	paletteSize int
+	quality     int
+}
+
+// PNG specifies that images are encoded as PNG with the provided compression
+// level, i.e. `png.BestCompression`.
+func (e *ImageEncoding) PNG(level png.CompressionLevel) {
+	e.format = encodingPNG
+	e.compression = level
+}
+
+// Palette quantizes PNG images to a palette of at most the provided number of
+// colors (between 2 and 256). This substantially reduces the size of flat
+// artwork such as icons and glyphs.
+func (e *ImageEncoding) Palette(size int) {
+	e.paletteSize = size
+}
+
+// JPEG specifies that images are encoded as JPEG with the provided quality
+// (between 1 and 100). JPEG is intended for photographic image sets and does
+// not support transparency; use `AssetSource.Background` to control how
+// transparent areas are filled. App icons must be encoded as PNG.
+func (e *ImageEncoding) JPEG(quality int) {
+	e.format = encodingJPEG
+	e.quality = quality
+}
+
+// Validate will validate the image encoding configuration.
+func (e *ImageEncoding) Validate() error {
+	switch e.format {
+	case "", encodingPNG:
+		if e.compression > png.DefaultCompression || e.compression < png.BestCompression {
+			return fmt.Errorf("PNG compression level is invalid (%v)", e.compression)
+		}
+	case encodingJPEG:
+		if e.quality < 1 || e.quality > 100 {
+			return fmt.Errorf("JPEG quality is invalid (%v), must be between 1 and 100", e.quality)
+		}
+
+		if e.paletteSize != 0 {
+			return fmt.Errorf("Palette quantization is only available for PNG images")
+		}
+	default:
+		return fmt.Errorf("Unsupported image encoding (%v)", e.format)
+	}
+
+	if e.paletteSize != 0 && (e.paletteSize < 2 || e.paletteSize > 256) {
+		return fmt.Errorf("Palette size is invalid (%v), must be between 2 and 256", e.paletteSize)
+	}
+
+	return nil
+}
+
+func (e *ImageEncoding) isPNG() bool {
+	return e.format == "" || e.format == encodingPNG
+}
+
+func (e *ImageEncoding) extension() string {
+	if e.format == encodingJPEG {
+		return ".jpg"
+	}
+
+	return ".png"
+}
+
+func (e *ImageEncoding) encode(w io.Writer, img image.Image) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	if e.format == encodingJPEG {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: e.quality})
+	}
+
+	if e.paletteSize != 0 {
+		img = quantize(img, e.paletteSize)
+	}
+
+	encoder := png.Encoder{CompressionLevel: e.compression}
+	return encoder.Encode(w, img)
+}
+
+type colorBox struct {
+	colors []imagecolor.NRGBA
+	counts map[imagecolor.NRGBA]int
+}
+
+func (b colorBox) channel(c imagecolor.NRGBA, idx int) uint8 {
+	return [4]uint8{c.R, c.G, c.B, c.A}[idx]
+}
+
+// widest returns the channel with the largest range and the size of that range.
+func (b colorBox) widest() (int, int) {
+	channel, width := 0, -1
+
+	for idx := 0; idx < 4; idx++ {
+		min, max := 255, 0
+		for _, c := range b.colors {
+			v := int(b.channel(c, idx))
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+
+		if max-min > width {
+			channel, width = idx, max-min
+		}
+	}
+
+	return channel, width
+}
+
+func (b colorBox) average() imagecolor.NRGBA {
+	var r, g, bl, a, total int
+	for _, c := range b.colors {
+		n := b.counts[c]
+		r += int(c.R) * n
+		g += int(c.G) * n
+		bl += int(c.B) * n
+		a += int(c.A) * n
+		total += n
+	}
+
+	return imagecolor.NRGBA{
+		R: uint8(r / total),
+		G: uint8(g / total),
+		B: uint8(bl / total),
+		A: uint8(a / total),
+	}
+}
+
+// quantize reduces the image to a palette of at most `size` colors using the
+// median cut algorithm. Images that already have few enough colors keep their
+// exact colors.
+func quantize(img image.Image, size int) *image.Paletted {
+	bounds := img.Bounds()
+	counts := map[imagecolor.NRGBA]int{}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := imagecolor.NRGBAModel.Convert(img.At(x, y)).(imagecolor.NRGBA)
+			counts[c]++
+		}
+	}
+
+	colors := make([]imagecolor.NRGBA, 0, len(counts))
+	for c := range counts {
+		colors = append(colors, c)
+	}
+
+	// Sort so the palette is deterministic regardless of map iteration order.
+	sort.Slice(colors, func(i, j int) bool {
+		a, b := colors[i], colors[j]
+		if a.R != b.R {
+			return a.R < b.R
+		}
+		if a.G != b.G {
+			return a.G < b.G
+		}
+		if a.B != b.B {
+			return a.B < b.B
+		}
+		return a.A < b.A
+	})
+
+	palette := imagecolor.Palette{}
+
+	if len(colors) <= size {
+		for _, c := range colors {
+			palette = append(palette, c)
+		}
+	} else {
+		boxes := []colorBox{{colors: colors, counts: counts}}
+
+		for len(boxes) < size {
+			split, channel, width := -1, 0, 0
+			for idx, box := range boxes {
+				if len(box.colors) < 2 {
+					continue
+				}
+
+				if c, w := box.widest(); w > width {
+					split, channel, width = idx, c, w
+				}
+			}
+
+			if split == -1 {
+				break
+			}
+
+			box := boxes[split]
+			sort.SliceStable(box.colors, func(i, j int) bool {
+				return box.channel(box.colors[i], channel) < box.channel(box.colors[j], channel)
+			})
+
+			median := len(box.colors) / 2
+			boxes[split] = colorBox{colors: box.colors[:median], counts: counts}
+			boxes = append(boxes, colorBox{colors: box.colors[median:], counts: counts})
+		}
+
+		for _, box := range boxes {
+			palette = append(palette, box.average())
+		}
+	}
+
+	dst := image.NewPaletted(bounds, palette)
+	draw.Draw(dst, bounds, img, bounds.Min, draw.Src)
+
+	return dst
+}
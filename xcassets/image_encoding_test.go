@@ -0,0 +1,151 @@
+package xcassets
+
+import (
+	"bytes"
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"os"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestImageEncoding_Validate(t *testing.T) {
+	type fields struct {
+		encoding func() *ImageEncoding
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Default encoding should be valid",
+			fields: fields{
+				encoding: func() *ImageEncoding {
+					return &ImageEncoding{}
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "PNG with palette should be valid",
+			fields: fields{
+				encoding: func() *ImageEncoding {
+					e := &ImageEncoding{}
+					e.PNG(png.BestCompression)
+					e.Palette(64)
+					return e
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid palette size should fail",
+			fields: fields{
+				encoding: func() *ImageEncoding {
+					e := &ImageEncoding{}
+					e.Palette(512)
+					return e
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid JPEG quality should fail",
+			fields: fields{
+				encoding: func() *ImageEncoding {
+					e := &ImageEncoding{}
+					e.JPEG(0)
+					return e
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "JPEG with palette should fail",
+			fields: fields{
+				encoding: func() *ImageEncoding {
+					e := &ImageEncoding{}
+					e.JPEG(80)
+					e.Palette(16)
+					return e
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.fields.encoding()
+			err := e.Validate()
+
+			if tt.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestImageEncoding_Palette(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, imagecolor.NRGBA{R: uint8(x * 16), G: uint8(y * 16), A: 255})
+		}
+	}
+
+	e := ImageEncoding{}
+	e.Palette(8)
+
+	buf := bytes.Buffer{}
+	assert.Nil(t, e.encode(&buf, img))
+
+	decoded, err := png.Decode(&buf)
+	assert.Nil(t, err)
+
+	paletted, ok := decoded.(*image.Paletted)
+	assert.True(t, ok, "Quantized images should be encoded with a palette")
+	assert.LessOrEqual(t, len(paletted.Palette), 8)
+}
+
+func TestAsset_JPEGEncoding(t *testing.T) {
+	builder := Asset("Photo", func(b *AssetBuilder) {
+		b.Encoding.JPEG(85)
+		b.Asset(func(d *AssetDefinition) {
+			d.Devices.Universal()
+			d.Source.File("./testdata/Icon.png")
+			d.Source.Size(256, 256)
+		})
+	})
+
+	output, err := builder.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "Photo-universal-256x256@1x.jpg", output.Images[0].Filename)
+
+	err = builder.SaveTo("./_test/", true)
+	assert.Nil(t, err)
+
+	_, err = os.Stat("./_test/Photo.imageset/Photo-universal-256x256@1x.jpg")
+	assert.Nil(t, err)
+	assert.Nil(t, os.RemoveAll("./_test/Photo.imageset"))
+}
+
+func TestAppIcon_Encoding(t *testing.T) {
+	builder := AppIcon("AppIcon", func(b *AppIconBuilder) {
+		b.File("./testdata/Icon.png")
+		b.Encoding.JPEG(85)
+		b.AppStore()
+	})
+
+	assert.NotNil(t, builder.Validate(), "App icons must be encoded as PNG")
+
+	builder.Encoding.PNG(png.BestCompression)
+	output, err := builder.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "AppIcon-1024x1024@1x.png", output.Images[0].Filename)
+	assert.Equal(t, ".png", output.Inputs[0].Extension, "Images should be written with the encoding's extension")
+}
@@ -0,0 +1,204 @@
+package xcassets
+
+import (
+	"fmt"
+	"image"
+	imagecolor "image/color"
+	"image/draw"
+	"strings"
+)
+
+// ImageTransform is a single step in an asset source transform pipeline.
+// Transforms run after the source image is loaded and before it is resized to
+// each output size.
+type ImageTransform interface {
+	// Key uniquely identifies the transform and its parameters. It is used to
+	// cache processed images so that sources sharing a transform chain are only
+	// processed once.
+	Key() string
+
+	// Bounds returns the bounds of the transformed image for a source image with
+	// the provided bounds, or an error if the transform is misconfigured.
+	Bounds(r image.Rectangle) (image.Rectangle, error)
+
+	// Transform applies the transform to the provided image.
+	Transform(img image.Image) (image.Image, error)
+}
+
+func transformKey(transforms []ImageTransform) string {
+	keys := make([]string, len(transforms))
+	for idx, t := range transforms {
+		keys[idx] = t.Key()
+	}
+
+	return strings.Join(keys, "|")
+}
+
+func colorKey(c imagecolor.Color) string {
+	r, g, b, a := c.RGBA()
+	return fmt.Sprintf("%04x%04x%04x%04x", r, g, b, a)
+}
+
+type padTransform struct {
+	ratio float64
+}
+
+func (t padTransform) Key() string {
+	return fmt.Sprintf("pad(%v)", t.ratio)
+}
+
+func (t padTransform) padding(r image.Rectangle) int {
+	side := r.Dx()
+	if r.Dy() > side {
+		side = r.Dy()
+	}
+
+	return int(float64(side) * t.ratio)
+}
+
+func (t padTransform) Bounds(r image.Rectangle) (image.Rectangle, error) {
+	if t.ratio < 0 {
+		return image.Rectangle{}, fmt.Errorf("Padding is invalid (%v), must be greater than or equal to 0", t.ratio)
+	}
+
+	p := t.padding(r)
+	return image.Rect(0, 0, r.Dx()+p*2, r.Dy()+p*2), nil
+}
+
+func (t padTransform) Transform(img image.Image) (image.Image, error) {
+	bounds, err := t.Bounds(img.Bounds())
+	if err != nil {
+		return nil, err
+	}
+
+	p := t.padding(img.Bounds())
+	dst := image.NewNRGBA(bounds)
+	draw.Draw(dst, img.Bounds().Sub(img.Bounds().Min).Add(image.Pt(p, p)), img, img.Bounds().Min, draw.Src)
+
+	return dst, nil
+}
+
+type backgroundTransform struct {
+	color imagecolor.Color
+}
+
+func (t backgroundTransform) Key() string {
+	return fmt.Sprintf("background(%v)", colorKey(t.color))
+}
+
+func (t backgroundTransform) Bounds(r image.Rectangle) (image.Rectangle, error) {
+	if t.color == nil {
+		return image.Rectangle{}, fmt.Errorf("No background color specified")
+	}
+
+	return r, nil
+}
+
+func (t backgroundTransform) Transform(img image.Image) (image.Image, error) {
+	if _, err := t.Bounds(img.Bounds()); err != nil {
+		return nil, err
+	}
+
+	dst := image.NewNRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(t.color), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	return dst, nil
+}
+
+type tintTransform struct {
+	color  imagecolor.Color
+	amount float64
+}
+
+func (t tintTransform) Key() string {
+	return fmt.Sprintf("tint(%v,%v)", colorKey(t.color), t.amount)
+}
+
+func (t tintTransform) Bounds(r image.Rectangle) (image.Rectangle, error) {
+	if t.color == nil {
+		return image.Rectangle{}, fmt.Errorf("No tint color specified")
+	}
+
+	if t.amount < 0 || t.amount > 1 {
+		return image.Rectangle{}, fmt.Errorf("Tint amount is invalid (%v), must be between 0 and 1", t.amount)
+	}
+
+	return r, nil
+}
+
+func (t tintTransform) Transform(img image.Image) (image.Image, error) {
+	if _, err := t.Bounds(img.Bounds()); err != nil {
+		return nil, err
+	}
+
+	tint := imagecolor.NRGBAModel.Convert(t.color).(imagecolor.NRGBA)
+	blend := func(v, to uint8) uint8 {
+		return uint8(float64(v)*(1-t.amount) + float64(to)*t.amount + 0.5)
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(bounds)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := imagecolor.NRGBAModel.Convert(img.At(x, y)).(imagecolor.NRGBA)
+			dst.SetNRGBA(x, y, imagecolor.NRGBA{
+				R: blend(c.R, tint.R),
+				G: blend(c.G, tint.G),
+				B: blend(c.B, tint.B),
+				A: c.A,
+			})
+		}
+	}
+
+	return dst, nil
+}
+
+type cropTransform struct {
+	rect   image.Rectangle
+	square bool
+}
+
+func (t cropTransform) Key() string {
+	if t.square {
+		return "crop(square)"
+	}
+
+	return fmt.Sprintf("crop(%v)", t.rect)
+}
+
+func (t cropTransform) crop(r image.Rectangle) image.Rectangle {
+	if !t.square {
+		return t.rect.Add(r.Min).Intersect(r)
+	}
+
+	side := r.Dx()
+	if r.Dy() < side {
+		side = r.Dy()
+	}
+
+	min := image.Pt(r.Min.X+(r.Dx()-side)/2, r.Min.Y+(r.Dy()-side)/2)
+	return image.Rectangle{Min: min, Max: min.Add(image.Pt(side, side))}
+}
+
+func (t cropTransform) Bounds(r image.Rectangle) (image.Rectangle, error) {
+	crop := t.crop(r)
+	if crop.Empty() {
+		return image.Rectangle{}, fmt.Errorf("Crop rectangle %v does not intersect the image bounds %v", t.rect, r.Sub(r.Min))
+	}
+
+	return crop.Sub(crop.Min), nil
+}
+
+func (t cropTransform) Transform(img image.Image) (image.Image, error) {
+	bounds, err := t.Bounds(img.Bounds())
+	if err != nil {
+		return nil, err
+	}
+
+	dst := image.NewNRGBA(bounds)
+	draw.Draw(dst, bounds, img, t.crop(img.Bounds()).Min, draw.Src)
+
+	return dst, nil
+}
@@ -0,0 +1,195 @@
+package xcassets
+
+import (
+	"image"
+	imagecolor "image/color"
+	"image/draw"
+	"os"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAssetSource_Transform(t *testing.T) {
+	type fields struct {
+		source func() *AssetSource
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		size   image.Rectangle
+		color  imagecolor.Color
+		bounds image.Rectangle
+		at     image.Point
+		want   imagecolor.NRGBA
+	}{
+		{
+			name: "Padding should be transparent",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Pad(0.25)
+					return s
+				},
+			},
+			size:   image.Rect(0, 0, 8, 4),
+			color:  imagecolor.White,
+			bounds: image.Rect(0, 0, 12, 8),
+			at:     image.Pt(0, 0),
+			want:   imagecolor.NRGBA{},
+		},
+		{
+			name: "Padded source images should be centered",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Pad(0.25)
+					return s
+				},
+			},
+			size:   image.Rect(0, 0, 8, 4),
+			color:  imagecolor.White,
+			bounds: image.Rect(0, 0, 12, 8),
+			at:     image.Pt(2, 2),
+			want:   imagecolor.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+		{
+			name: "Backgrounds should fill transparent pixels",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Background(imagecolor.White)
+					return s
+				},
+			},
+			size:   image.Rect(0, 0, 2, 2),
+			color:  imagecolor.Transparent,
+			bounds: image.Rect(0, 0, 2, 2),
+			at:     image.Pt(1, 1),
+			want:   imagecolor.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+		{
+			name: "Tints should replace the color and keep the alpha",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Tint(imagecolor.NRGBA{R: 255, A: 255}, 1)
+					return s
+				},
+			},
+			size:   image.Rect(0, 0, 2, 2),
+			color:  imagecolor.NRGBA{B: 255, A: 128},
+			bounds: image.Rect(0, 0, 2, 2),
+			at:     image.Pt(0, 0),
+			want:   imagecolor.NRGBA{R: 255, A: 128},
+		},
+		{
+			name: "Square crops should use the shortest side",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.CropSquare()
+					return s
+				},
+			},
+			size:   image.Rect(0, 0, 10, 4),
+			color:  imagecolor.White,
+			bounds: image.Rect(0, 0, 4, 4),
+			at:     image.Pt(0, 0),
+			want:   imagecolor.NRGBA{R: 255, G: 255, B: 255, A: 255},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewNRGBA(tt.size)
+			draw.Draw(src, src.Bounds(), image.NewUniform(tt.color), image.Point{}, draw.Src)
+
+			img, err := tt.fields.source().transform(src)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.bounds, img.Bounds())
+			assert.Equal(t, tt.want, imagecolor.NRGBAModel.Convert(img.At(tt.at.X, tt.at.Y)))
+		})
+	}
+}
+
+func TestAssetSource_TransformBounds(t *testing.T) {
+	type fields struct {
+		source func() *AssetSource
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		size   image.Rectangle
+	}{
+		{
+			name: "Negative padding should fail",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Pad(-1)
+					return s
+				},
+			},
+			size: image.Rect(0, 0, 8, 4),
+		},
+		{
+			name: "Tint amounts above 1 should fail",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Tint(imagecolor.White, 2)
+					return s
+				},
+			},
+			size: image.Rect(0, 0, 2, 2),
+		},
+		{
+			name: "Crops outside of the image should fail",
+			fields: fields{
+				source: func() *AssetSource {
+					s := &AssetSource{}
+					s.Crop(image.Rect(20, 20, 30, 30))
+					return s
+				},
+			},
+			size: image.Rect(0, 0, 10, 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.fields.source().transformBounds(tt.size)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestAssetLoader_KeyIncludesTransforms(t *testing.T) {
+	loader := assetLoader{}
+
+	s1 := AssetSource{}
+	s1.File("./testdata/Icon.png")
+
+	s2 := AssetSource{}
+	s2.Apply(s1)
+	s2.Pad(0.1)
+
+	assert.Equal(t, "./testdata/Icon.png", loader.Key(s1))
+	assert.NotEqual(t, loader.Key(s1), loader.Key(s2))
+}
+
+func TestAppIcon_Transforms(t *testing.T) {
+	builder := AppIcon("TransformedIcon", func(b *AppIconBuilder) {
+		b.File("./testdata/Icon.png")
+		b.Phone().Configure(func(b *AppIconPhone) {
+			b.Notification.File("./testdata/Icon.png")
+			b.Notification.Pad(0.1)
+			b.Notification.Background(imagecolor.White)
+		})
+	})
+
+	assert.Nil(t, builder.Validate())
+
+	err := builder.SaveTo("./_test/", true)
+	assert.Nil(t, err)
+	assert.Nil(t, os.RemoveAll("./_test/TransformedIcon.appiconset"))
+}
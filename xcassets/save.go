@@ -0,0 +1,233 @@
+package xcassets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/illyabusigin/apptools/filesystem"
+)
+
+// FileAction describes the change a save would make to a single file.
+type FileAction string
+
+const (
+	// FileCreated indicates the file does not exist yet.
+	FileCreated FileAction = "create"
+
+	// FileModified indicates the file exists with different contents.
+	FileModified FileAction = "modify"
+
+	// FileUnchanged indicates the file exists with identical contents.
+	FileUnchanged FileAction = "unchanged"
+
+	// FileDeleted indicates the file exists but is no longer generated.
+	FileDeleted FileAction = "delete"
+)
+
+// FileChange describes a planned change to a single file, as returned by
+// `DryRun`.
+type FileChange struct {
+	Path   string
+	Action FileAction
+
+	// Diff contains a line diff for modified text files such as Contents.json.
+	Diff string
+}
+
+func (c FileChange) String() string {
+	return fmt.Sprintf("%v %v", c.Action, c.Path)
+}
+
+// saveFolder writes files into folder inside path. The folder is first built
+// in a temporary sibling folder and then renamed into place so an interrupted
+// save never leaves a partially written folder behind.
+func saveFolder(fsys filesystem.FileSystem, path, folder string, files map[string][]byte, overwrite bool) error {
+	stat, err := fsys.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("Path does not exist: %w", err)
+		}
+
+		return fmt.Errorf("Failed to validate path: %w", err)
+	}
+
+	if !stat.IsDir() {
+		return fmt.Errorf("SaveTo path must be a directory")
+	}
+
+	dest := filepath.Join(path, folder)
+	exists := false
+	if _, err := fsys.Stat(dest); err == nil {
+		exists = true
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to validate %v folder: %w", folder, err)
+	}
+
+	if exists && !overwrite {
+		return fmt.Errorf("Folder %v already exists", folder)
+	}
+
+	suffix := time.Now().UnixNano()
+	tmp := filepath.Join(path, fmt.Sprintf(".%v.tmp-%d", folder, suffix))
+
+	if err := fsys.MkdirAll(tmp, os.ModePerm); err != nil {
+		return fmt.Errorf("Failed to create %v folder: %w", folder, err)
+	}
+
+	for _, name := range sortedFileNames(files) {
+		if err := fsys.WriteFile(filepath.Join(tmp, name), files[name], os.ModePerm); err != nil {
+			fsys.RemoveAll(tmp)
+			return fmt.Errorf("Failed to write %v to file: %w", name, err)
+		}
+	}
+
+	if !exists {
+		if err := fsys.Rename(tmp, dest); err != nil {
+			fsys.RemoveAll(tmp)
+			return fmt.Errorf("Failed to move %v folder into place: %w", folder, err)
+		}
+
+		return nil
+	}
+
+	backup := filepath.Join(path, fmt.Sprintf(".%v.old-%d", folder, suffix))
+	if err := fsys.Rename(dest, backup); err != nil {
+		fsys.RemoveAll(tmp)
+		return fmt.Errorf("Failed to replace %v folder: %w", folder, err)
+	}
+
+	if err := fsys.Rename(tmp, dest); err != nil {
+		fsys.Rename(backup, dest)
+		fsys.RemoveAll(tmp)
+		return fmt.Errorf("Failed to move %v folder into place: %w", folder, err)
+	}
+
+	if err := fsys.RemoveAll(backup); err != nil {
+		return fmt.Errorf("Failed to remove previous %v folder: %w", folder, err)
+	}
+
+	return nil
+}
+
+// planFolder compares files against the existing contents of folder inside
+// path without modifying the file system.
+func planFolder(fsys filesystem.FileSystem, path, folder string, files map[string][]byte) ([]FileChange, error) {
+	dest := filepath.Join(path, folder)
+	changes := []FileChange{}
+
+	existing := map[string]bool{}
+	entries, err := fsys.ReadDir(dest)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Failed to read %v folder: %w", folder, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			existing[entry.Name()] = true
+		}
+	}
+
+	for _, name := range sortedFileNames(files) {
+		change := FileChange{
+			Path:   filepath.Join(dest, name),
+			Action: FileCreated,
+		}
+
+		if existing[name] {
+			old, err := fsys.ReadFile(change.Path)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read %v: %w", change.Path, err)
+			}
+
+			change.Action = FileUnchanged
+			if !bytes.Equal(old, files[name]) {
+				change.Action = FileModified
+				if filepath.Ext(name) == ".json" {
+					change.Diff = lineDiff(string(old), string(files[name]))
+				}
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; !ok && !entry.IsDir() {
+			changes = append(changes, FileChange{
+				Path:   filepath.Join(dest, entry.Name()),
+				Action: FileDeleted,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// writeFiles writes files into the existing folder at path.
+func writeFiles(fsys filesystem.FileSystem, path string, files map[string][]byte) error {
+	for _, name := range sortedFileNames(files) {
+		if err := fsys.WriteFile(filepath.Join(path, name), files[name], os.ModePerm); err != nil {
+			return fmt.Errorf("Failed to write image file, error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func sortedFileNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// lineDiff returns a minimal line diff between a and b, prefixing removed
+// lines with "-", added lines with "+" and unchanged lines with " ".
+func lineDiff(a, b string) string {
+	x, y := strings.Split(a, "\n"), strings.Split(b, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:], y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	buf := strings.Builder{}
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			buf.WriteString(" " + x[i] + "\n")
+			i++
+			j++
+		case j < len(y) && (i == len(x) || lcs[i][j+1] >= lcs[i+1][j]):
+			buf.WriteString("+" + y[j] + "\n")
+			j++
+		default:
+			buf.WriteString("-" + x[i] + "\n")
+			i++
+		}
+	}
+
+	return buf.String()
+}
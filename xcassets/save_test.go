@@ -0,0 +1,294 @@
+package xcassets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/illyabusigin/apptools/filesystem"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestAssetBuilder_SaveToFS(t *testing.T) {
+	type fields struct {
+		builder func() *AssetBuilder
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		existing  map[string]string
+		path      string
+		overwrite bool
+		wantErr   bool
+		want      []string
+	}{
+		{
+			name: "Saving should write every image and Contents.json",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(16, 16)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			path: "assets",
+			want: []string{
+				"assets/Logo.imageset/Contents.json",
+				"assets/Logo.imageset/Logo-universal-16x16@1x.png",
+				"assets/Logo.imageset/Logo-universal-32x32@2x.png",
+				"assets/Logo.imageset/Logo-universal-48x48@3x.png",
+			},
+		},
+		{
+			name: "Saving over an existing folder without overwrite should fail",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(16, 16)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			existing: map[string]string{"assets/Logo.imageset/Contents.json": "{}"},
+			path:     "assets",
+			wantErr:  true,
+			want:     []string{"assets/Logo.imageset/Contents.json"},
+		},
+		{
+			name: "Saving over an existing folder with overwrite should replace it",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(8, 8)
+							d.Source.MaxScaleFactor(1)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			existing: map[string]string{
+				"assets/Logo.imageset/Contents.json":               "{}",
+				"assets/Logo.imageset/Logo-universal-16x16@1x.png": "",
+			},
+			path:      "assets",
+			overwrite: true,
+			want: []string{
+				"assets/Logo.imageset/Contents.json",
+				"assets/Logo.imageset/Logo-universal-8x8@1x.png",
+			},
+		},
+		{
+			name: "Saving to a missing path should fail",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(16, 16)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			path:      "missing",
+			overwrite: true,
+			wantErr:   true,
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			assert.Nil(t, fsys.MkdirAll("assets", os.ModePerm))
+
+			for name, data := range tt.existing {
+				assert.Nil(t, fsys.MkdirAll(filepath.Dir(name), os.ModePerm))
+				assert.Nil(t, fsys.WriteFile(name, []byte(data), os.ModePerm))
+			}
+
+			err := tt.fields.builder().SaveToFS(fsys, tt.path, tt.overwrite)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, tt.want, fsys.Files())
+		})
+	}
+}
+
+func TestAssetBuilder_DryRun(t *testing.T) {
+	type fields struct {
+		builder func() *AssetBuilder
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		existing map[string]string
+		want     map[string]FileAction
+	}{
+		{
+			name: "Files missing from the folder should be created",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(16, 16)
+							d.Source.MaxScaleFactor(1)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			want: map[string]FileAction{
+				"assets/Logo.imageset/Contents.json":               FileCreated,
+				"assets/Logo.imageset/Logo-universal-16x16@1x.png": FileCreated,
+			},
+		},
+		{
+			name: "Files no longer generated should be deleted and changed files modified",
+			fields: fields{
+				builder: func() *AssetBuilder {
+					return Asset("Logo", func(b *AssetBuilder) {
+						b.Asset(func(d *AssetDefinition) {
+							d.Devices.Universal()
+							d.Source.Size(8, 8)
+							d.Source.MaxScaleFactor(1)
+							d.Source.Color(func(d *ColorDefinition) {
+								d.Hex("#262d44")
+							})
+						})
+					})
+				},
+			},
+			existing: map[string]string{
+				"assets/Logo.imageset/Contents.json":               "{}",
+				"assets/Logo.imageset/Logo-universal-16x16@1x.png": "",
+			},
+			want: map[string]FileAction{
+				"assets/Logo.imageset/Contents.json":               FileModified,
+				"assets/Logo.imageset/Logo-universal-8x8@1x.png":   FileCreated,
+				"assets/Logo.imageset/Logo-universal-16x16@1x.png": FileDeleted,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := filesystem.NewMemoryFileSystem()
+			assert.Nil(t, fsys.MkdirAll("assets/Logo.imageset", os.ModePerm))
+
+			for name, data := range tt.existing {
+				assert.Nil(t, fsys.WriteFile(name, []byte(data), os.ModePerm))
+			}
+
+			before := fsys.Files()
+
+			changes, err := tt.fields.builder().DryRun(fsys, "assets")
+			assert.Nil(t, err)
+
+			actions := map[string]FileAction{}
+			for _, c := range changes {
+				actions[c.Path] = c.Action
+			}
+
+			assert.Equal(t, tt.want, actions)
+			assert.Equal(t, before, fsys.Files(), "Dry runs should not write any files")
+		})
+	}
+}
+
+func TestAssetBuilder_DryRunUnchanged(t *testing.T) {
+	builder := Asset("Logo", func(b *AssetBuilder) {
+		b.Asset(func(d *AssetDefinition) {
+			d.Devices.Universal()
+			d.Source.Size(16, 16)
+			d.Source.Color(func(d *ColorDefinition) {
+				d.Hex("#262d44")
+			})
+		})
+	})
+
+	fsys := filesystem.NewMemoryFileSystem()
+	assert.Nil(t, fsys.MkdirAll("assets", os.ModePerm))
+	assert.Nil(t, builder.SaveToFS(fsys, "assets", false))
+
+	changes, err := builder.DryRun(fsys, "assets")
+	assert.Nil(t, err)
+	assert.Len(t, changes, 4)
+	for _, c := range changes {
+		assert.Equal(t, FileUnchanged, c.Action)
+	}
+}
+
+func TestAssetOutput_WriteImagesFS(t *testing.T) {
+	builder := Asset("Logo", func(b *AssetBuilder) {
+		b.Asset(func(d *AssetDefinition) {
+			d.Devices.Universal()
+			d.Source.Size(16, 16)
+			d.Source.MaxScaleFactor(2)
+			d.Source.Color(func(d *ColorDefinition) {
+				d.Hex("#262d44")
+			})
+		})
+	})
+
+	output, err := builder.Build()
+	assert.Nil(t, err)
+
+	fsys := filesystem.NewMemoryFileSystem()
+	assert.NotNil(t, output.WriteImagesFS(fsys, "missing"), "Writing to a missing folder should fail")
+
+	assert.Nil(t, fsys.MkdirAll("images", os.ModePerm))
+	assert.Nil(t, output.WriteImagesFS(fsys, "images"))
+	assert.Equal(t, []string{
+		"images/Logo-universal-16x16@1x.png",
+		"images/Logo-universal-32x32@2x.png",
+	}, fsys.Files())
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "Unchanged lines should be prefixed with a space",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: " a\n b\n",
+		},
+		{
+			name: "Removed and added lines should be prefixed with - and +",
+			a:    "a\nb\nc",
+			b:    "a\nc\nd",
+			want: " a\n-b\n c\n+d\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lineDiff(tt.a, tt.b))
+		})
+	}
+}
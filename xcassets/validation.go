@@ -0,0 +1,42 @@
+package xcassets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported by the xcassets builders.
+const (
+	CodeNoDefinitions          = "no-definitions"
+	CodeInvalidColor           = "invalid-color"
+	CodeInvalidDevices         = "invalid-devices"
+	CodeInvalidSource          = "invalid-source"
+	CodeInvalidEncoding        = "invalid-encoding"
+	CodeOverlappingDevices     = "overlapping-devices"
+	CodeOverlappingAppearances = "overlapping-appearances"
+)
+
+// overlapReport reports the devices and appearances shared between two
+// definitions. `other` is the path of the second definition, if known.
+func overlapReport(other string, d1, d2 *Devices, a1, a2 *Appearance) *validation.Report {
+	r := &validation.Report{}
+
+	with := ""
+	if other != "" {
+		with = fmt.Sprintf(" with %v", other)
+	}
+
+	if intersection := d1.intersects(d2); len(intersection) > 0 {
+		r.AddError("devices", CodeOverlappingDevices, "",
+			fmt.Errorf("Devices overlap%v (%v) - they must be unique", with, strings.Join(intersection, ",")))
+	}
+
+	if intersection := a1.intersects(a2); len(intersection) > 0 {
+		r.AddError("appearance", CodeOverlappingAppearances, "",
+			fmt.Errorf("Appearances overlap%v (%v) - they must be unique", with, strings.Join(intersection, ",")))
+	}
+
+	return r
+}
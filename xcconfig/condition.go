@@ -0,0 +1,64 @@
+package xcconfig
+
+import (
+	"fmt"
+	"path"
+)
+
+// Condition restricts a build setting assignment to matching builds, i.e.
+// `[sdk=iphoneos*]`. Values may contain `*` wildcards.
+// See https://help.apple.com/xcode/#/dev745c5c974 for more information.
+type Condition struct {
+	Key   string
+	Value string
+}
+
+// SDK returns a condition matching the SDK, i.e. `iphoneos*`.
+func SDK(pattern string) Condition {
+	return Condition{Key: "sdk", Value: pattern}
+}
+
+// Arch returns a condition matching the architecture, i.e. `arm64`.
+func Arch(pattern string) Condition {
+	return Condition{Key: "arch", Value: pattern}
+}
+
+// Config returns a condition matching the build configuration, i.e. `Debug`.
+func Config(pattern string) Condition {
+	return Condition{Key: "config", Value: pattern}
+}
+
+var conditionKeys = map[string]bool{
+	"sdk":    true,
+	"arch":   true,
+	"config": true,
+}
+
+// String returns the condition in xcconfig syntax, i.e. `[sdk=iphoneos*]`.
+func (c Condition) String() string {
+	return fmt.Sprintf("[%v=%v]", c.Key, c.Value)
+}
+
+// matches returns true if every condition is satisfied by a condition of the
+// build context with the same key.
+func matches(conditions []Condition, context []Condition) bool {
+	for _, c := range conditions {
+		found := false
+		for _, ctx := range context {
+			if ctx.Key != c.Key {
+				continue
+			}
+
+			if ok, err := path.Match(c.Value, ctx.Value); err == nil && ok {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
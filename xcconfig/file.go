@@ -0,0 +1,290 @@
+package xcconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/illyabusigin/apptools/validation"
+)
+
+// Validation codes reported by the xcconfig builder.
+const (
+	CodeMissingProperty     = validation.CodeMissingProperty
+	CodeInvalidSettingName  = "invalid-setting-name"
+	CodeInvalidCondition    = "invalid-condition"
+	CodeInvalidSettingValue = "invalid-setting-value"
+)
+
+var (
+	// ErrMissingRequiredProperty is the error returned for missing properties
+	ErrMissingRequiredProperty = validation.ErrMissingRequiredProperty
+
+	// ErrInvalidSettingName is the error returned for build setting names
+	// that are not identifiers, i.e. `PRODUCT_NAME`.
+	ErrInvalidSettingName = errors.New("Build setting names must start with a letter or '_' followed by letters, digits or '_'")
+
+	// ErrInvalidCondition is the error returned for conditions other than
+	// `sdk`, `arch` and `config`.
+	ErrInvalidCondition = errors.New("Conditions must be one of sdk, arch or config")
+
+	// ErrInvalidSettingValue is the error returned for values containing
+	// line breaks or `//`, which xcconfig files treat as a comment.
+	ErrInvalidSettingValue = errors.New("Build setting values cannot contain line breaks or '//'")
+)
+
+// Setting is a build setting assignment, optionally restricted to builds
+// matching its conditions.
+type Setting struct {
+	Name       string
+	Conditions []Condition
+	Value      string
+}
+
+// Include is an `#include` directive. Optional includes, `#include?`, are
+// ignored when the file does not exist.
+type Include struct {
+	Path     string
+	Optional bool
+
+	file *File
+}
+
+// statement is a line of an xcconfig file, either an include or a setting.
+type statement struct {
+	include *Include
+	setting *Setting
+}
+
+// File is a builder and parser for `.xcconfig` build configuration files.
+// See https://help.apple.com/xcode/#/dev745c5c974 for more information.
+type File struct {
+	skipValidation bool
+
+	statements []statement
+}
+
+// New returns a new xcconfig `File` builder.
+func New() *File {
+	return &File{}
+}
+
+// SkipValidation will skip all validation when building the file.
+func (f *File) SkipValidation() *File {
+	f.skipValidation = true
+	return f
+}
+
+// Include adds an `#include` directive for another xcconfig file, relative to
+// this file.
+func (f *File) Include(path string) *File {
+	f.statements = append(f.statements, statement{include: &Include{Path: path}})
+	return f
+}
+
+// OptionalInclude adds an `#include?` directive for another xcconfig file
+// that is ignored when it does not exist, i.e. for local overrides.
+func (f *File) OptionalInclude(path string) *File {
+	f.statements = append(f.statements, statement{include: &Include{Path: path, Optional: true}})
+	return f
+}
+
+// Set assigns a build setting, optionally restricted to builds matching the
+// conditions. Use `$(inherited)` in the value to extend the inherited value.
+func (f *File) Set(name, value string, conditions ...Condition) *File {
+	f.statements = append(f.statements, statement{setting: &Setting{Name: name, Value: value, Conditions: conditions}})
+	return f
+}
+
+// BundleID sets `PRODUCT_BUNDLE_IDENTIFIER`, i.e. `com.best.app`.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference#Product-Bundle-Identifier for more information.
+func (f *File) BundleID(id string, conditions ...Condition) *File {
+	return f.Set("PRODUCT_BUNDLE_IDENTIFIER", id, conditions...)
+}
+
+// InfoPlistFile sets `INFOPLIST_FILE`, the path of the Info.plist relative to
+// the project, i.e. the file written by `plist.PropertyList`.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference#Info.plist-File for more information.
+func (f *File) InfoPlistFile(path string, conditions ...Condition) *File {
+	return f.Set("INFOPLIST_FILE", path, conditions...)
+}
+
+// EntitlementsFile sets `CODE_SIGN_ENTITLEMENTS`, the path of the
+// entitlements file relative to the project, i.e. the file written by
+// `entitlements.Entitlements`.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference#Code-Signing-Entitlements for more information.
+func (f *File) EntitlementsFile(path string, conditions ...Condition) *File {
+	return f.Set("CODE_SIGN_ENTITLEMENTS", path, conditions...)
+}
+
+// AppIconName sets `ASSETCATALOG_COMPILER_APPICON_NAME`, the name of the app
+// icon set in the asset catalog, i.e. the icon set written by `xcassets`.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference#Primary-App-Icon-Set-Name for more information.
+func (f *File) AppIconName(name string, conditions ...Condition) *File {
+	return f.Set("ASSETCATALOG_COMPILER_APPICON_NAME", name, conditions...)
+}
+
+// Includes returns the include directives of the file.
+func (f *File) Includes() []Include {
+	includes := []Include{}
+	for _, s := range f.statements {
+		if s.include != nil {
+			includes = append(includes, *s.include)
+		}
+	}
+
+	return includes
+}
+
+// Assignments returns the build setting assignments of the file, excluding
+// the assignments of included files.
+func (f *File) Assignments() []Setting {
+	settings := []Setting{}
+	for _, s := range f.statements {
+		if s.setting != nil {
+			settings = append(settings, *s.setting)
+		}
+	}
+
+	return settings
+}
+
+// Settings resolves the build settings for a build matching the context,
+// i.e. `xcconfig.SDK("iphoneos17.0")` and `xcconfig.Config("Debug")`,
+// including the settings of loaded includes. Unconditional assignments are
+// applied first, in order, followed by the matching conditional assignments,
+// which take precedence. `$(inherited)` is replaced with the value assigned
+// so far.
+func (f *File) Settings(context ...Condition) Settings {
+	settings := Settings{}
+	f.resolve(settings, context, false)
+	f.resolve(settings, context, true)
+
+	return settings
+}
+
+func (f *File) resolve(settings Settings, context []Condition, conditional bool) {
+	for _, s := range f.statements {
+		if include := s.include; include != nil {
+			if include.file != nil {
+				include.file.resolve(settings, context, conditional)
+			}
+			continue
+		}
+
+		setting := s.setting
+		if (len(setting.Conditions) > 0) != conditional || !matches(setting.Conditions, context) {
+			continue
+		}
+
+		inherited := settings[setting.Name]
+		value := strings.Replace(setting.Value, "$(inherited)", inherited, -1)
+		value = strings.Replace(value, "${inherited}", inherited, -1)
+
+		settings[setting.Name] = strings.TrimSpace(value)
+	}
+}
+
+// Validate will validate the file and return any errors found.
+func (f *File) Validate() error {
+	return f.Report().Err()
+}
+
+// Report will validate the file and return a report containing every issue
+// found.
+func (f *File) Report() *validation.Report {
+	r := &validation.Report{}
+
+	includes, settings := 0, 0
+	for _, s := range f.statements {
+		if include := s.include; include != nil {
+			if strings.TrimSpace(include.Path) == "" {
+				r.AddError(validation.Join(validation.Index("Includes", includes), "Path"), CodeMissingProperty, "",
+					ErrMissingRequiredProperty)
+			}
+			includes++
+			continue
+		}
+
+		setting := s.setting
+		path := validation.Index("Settings", settings)
+		settings++
+
+		if !settingNameRegex.MatchString(setting.Name) {
+			r.AddError(validation.Join(path, "Name"), CodeInvalidSettingName, setting.Name,
+				fmt.Errorf("%w: %q", ErrInvalidSettingName, setting.Name))
+		}
+
+		for idx, c := range setting.Conditions {
+			if !conditionKeys[c.Key] || c.Value == "" {
+				r.AddError(validation.Join(path, validation.Index("Conditions", idx)), CodeInvalidCondition, setting.Name,
+					fmt.Errorf("%w: %v", ErrInvalidCondition, c))
+			}
+		}
+
+		if strings.ContainsAny(setting.Value, "\r\n") || strings.Contains(setting.Value, "//") {
+			r.AddError(validation.Join(path, "Value"), CodeInvalidSettingValue, setting.Name,
+				fmt.Errorf("%w: %q", ErrInvalidSettingValue, setting.Value))
+		}
+	}
+
+	return r
+}
+
+// Build will build the xcconfig file.
+func (f *File) Build() (string, error) {
+	if !f.skipValidation {
+		if err := f.Validate(); err != nil {
+			return "", err
+		}
+	}
+
+	buf := strings.Builder{}
+
+	for _, s := range f.statements {
+		if include := s.include; include != nil {
+			directive := "#include"
+			if include.Optional {
+				directive = "#include?"
+			}
+
+			fmt.Fprintf(&buf, "%v %q\n", directive, include.Path)
+			continue
+		}
+
+		setting := s.setting
+
+		buf.WriteString(setting.Name)
+		for _, c := range setting.Conditions {
+			buf.WriteString(c.String())
+		}
+
+		fmt.Fprintf(&buf, " = %v\n", setting.Value)
+	}
+
+	return buf.String(), nil
+}
+
+// Write the xcconfig file to the specified io.Writer.
+func (f *File) Write(w io.Writer) error {
+	data, err := f.Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write([]byte(data))
+
+	return err
+}
+
+// Save builds and writes the xcconfig file to the specified path.
+func (f *File) Save(path string) error {
+	data, err := f.Build()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, []byte(data), 0644)
+}
@@ -0,0 +1,167 @@
+package xcconfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/illyabusigin/apptools/validation"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestFile_Build(t *testing.T) {
+	type fields struct {
+		file func() *File
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "Includes and settings should be written in order",
+			fields: fields{
+				file: func() *File {
+					return New().
+						Include("Base.xcconfig").
+						OptionalInclude("Local.xcconfig").
+						BundleID("com.best.app").
+						BundleID("com.best.app.debug", Config("Debug")).
+						InfoPlistFile("App/Info.plist").
+						EntitlementsFile("App/App.entitlements").
+						AppIconName("AppIcon").
+						Set("OTHER_LDFLAGS", "$(inherited) -ObjC", SDK("iphoneos*"), Arch("arm64"))
+				},
+			},
+			want: `#include "Base.xcconfig"
+#include? "Local.xcconfig"
+PRODUCT_BUNDLE_IDENTIFIER = com.best.app
+PRODUCT_BUNDLE_IDENTIFIER[config=Debug] = com.best.app.debug
+INFOPLIST_FILE = App/Info.plist
+CODE_SIGN_ENTITLEMENTS = App/App.entitlements
+ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon
+OTHER_LDFLAGS[sdk=iphoneos*][arch=arm64] = $(inherited) -ObjC
+`,
+		},
+		{
+			name: "An empty file should build an empty string",
+			fields: fields{
+				file: func() *File {
+					return New()
+				},
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := tt.fields.file().Build()
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, out)
+
+			parsed, err := Parse(strings.NewReader(out))
+			assert.Nil(t, err)
+
+			roundTrip, err := parsed.Build()
+			assert.Nil(t, err)
+			assert.Equal(t, out, roundTrip)
+		})
+	}
+}
+
+func TestFile_Settings(t *testing.T) {
+	file := func() *File {
+		return New().
+			BundleID("com.best.app").
+			BundleID("com.best.app.debug", Config("Debug")).
+			Set("OTHER_LDFLAGS", "$(inherited) -ObjC", SDK("iphoneos*"), Arch("arm64")).
+			Set("OTHER_LDFLAGS", "-lz")
+	}
+
+	tests := []struct {
+		name       string
+		conditions []Condition
+		want       map[string]string
+	}{
+		{
+			name: "Unconditional settings should apply without conditions",
+			want: map[string]string{
+				"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app",
+				"OTHER_LDFLAGS":             "-lz",
+			},
+		},
+		{
+			name:       "Conditional settings should apply when their conditions match",
+			conditions: []Condition{SDK("iphoneos17.0"), Arch("arm64"), Config("Debug")},
+			want: map[string]string{
+				"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app.debug",
+				"OTHER_LDFLAGS":             "-lz -ObjC",
+			},
+		},
+		{
+			name:       "Conditional settings should not apply when their conditions differ",
+			conditions: []Condition{SDK("iphonesimulator17.0"), Arch("arm64")},
+			want: map[string]string{
+				"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app",
+				"OTHER_LDFLAGS":             "-lz",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := file().Settings(tt.conditions...)
+			for name, want := range tt.want {
+				assert.Equal(t, want, settings[name])
+			}
+		})
+	}
+}
+
+func TestFile_Report(t *testing.T) {
+	f := New().
+		Include("").
+		Set("1PRODUCT", "name").
+		Set("PRODUCT_NAME", "Best", Condition{Key: "platform", Value: "ios"}).
+		Set("API_URL", "https://best.app")
+
+	issues := f.Report().Errors()
+	assert.Len(t, issues, 4)
+	assert.Equal(t, "Includes[0].Path", issues[0].Path)
+	assert.Equal(t, CodeMissingProperty, issues[0].Code)
+	assert.True(t, errors.Is(issues[0], validation.ErrMissingRequiredProperty))
+	assert.Equal(t, "Settings[0].Name", issues[1].Path)
+	assert.True(t, errors.Is(issues[1], ErrInvalidSettingName))
+	assert.Equal(t, "Settings[1].Conditions[0]", issues[2].Path)
+	assert.True(t, errors.Is(issues[2], ErrInvalidCondition))
+	assert.Equal(t, "Settings[2].Value", issues[3].Path)
+	assert.True(t, errors.Is(issues[3], ErrInvalidSettingValue))
+
+	_, err := f.Build()
+	assert.NotNil(t, err)
+	assert.IsType(t, &validation.Report{}, err)
+
+	_, err = f.SkipValidation().Build()
+	assert.Nil(t, err)
+}
+
+func TestFile_Save(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xcconfig")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "App.xcconfig")
+	assert.Nil(t, New().BundleID("com.best.app").Save(path))
+
+	f, err := Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "com.best.app", f.Settings()["PRODUCT_BUNDLE_IDENTIFIER"])
+
+	buf := strings.Builder{}
+	assert.Nil(t, New().AppIconName("AppIcon").Write(&buf))
+	assert.Equal(t, "ASSETCATALOG_COMPILER_APPICON_NAME = AppIcon\n", buf.String())
+
+	assert.NotNil(t, New().Set("", "").Save(path))
+}
@@ -0,0 +1,128 @@
+package xcconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrInvalidSetting is the error returned for lines of an xcconfig file
+	// that are not valid includes or build setting assignments.
+	ErrInvalidSetting = errors.New("Invalid build setting")
+
+	// ErrIncludeCycle is the error returned for xcconfig files that include
+	// themselves, directly or indirectly.
+	ErrIncludeCycle = errors.New("Include cycle")
+
+	settingNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	includeRegex     = regexp.MustCompile(`^#include(\?)?\s*"([^"]*)"$`)
+	assignmentRegex  = regexp.MustCompile(`^([^\[=\s]+)((?:\[[^\]=]+=[^\]]*\])*)\s*=(.*)$`)
+	conditionRegex   = regexp.MustCompile(`\[([^\]=]+)=([^\]]*)\]`)
+)
+
+// Parse reads an xcconfig file. Includes are recorded but not loaded; use
+// `Load` to also load the included files.
+func Parse(r io.Reader) (*File, error) {
+	f := New()
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := stripComment(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		if match := includeRegex.FindStringSubmatch(text); match != nil {
+			f.statements = append(f.statements, statement{include: &Include{Path: match[2], Optional: match[1] == "?"}})
+			continue
+		}
+
+		match := assignmentRegex.FindStringSubmatch(text)
+		if match == nil || !settingNameRegex.MatchString(match[1]) {
+			return nil, fmt.Errorf("%w on line %d: %q", ErrInvalidSetting, line, text)
+		}
+
+		conditions := []Condition{}
+		for _, c := range conditionRegex.FindAllStringSubmatch(match[2], -1) {
+			conditions = append(conditions, Condition{Key: strings.TrimSpace(c[1]), Value: strings.TrimSpace(c[2])})
+		}
+
+		f.Set(match[1], strings.TrimSpace(match[3]), conditions...)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Load reads the xcconfig file at the specified path along with the files it
+// includes, which are resolved relative to the including file. Missing
+// optional includes are ignored.
+func Load(path string) (*File, error) {
+	return load(path, map[string]bool{})
+}
+
+func load(path string, loading map[string]bool) (*File, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if loading[abs] {
+		return nil, fmt.Errorf("%w: %v", ErrIncludeCycle, path)
+	}
+
+	loading[abs] = true
+	defer delete(loading, abs)
+
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	f, err := Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse %v: %w", path, err)
+	}
+
+	for _, s := range f.statements {
+		include := s.include
+		if include == nil {
+			continue
+		}
+
+		includePath := include.Path
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(filepath.Dir(path), includePath)
+		}
+
+		if _, err := os.Stat(includePath); os.IsNotExist(err) && include.Optional {
+			continue
+		}
+
+		if include.file, err = load(includePath, loading); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// stripComment removes `//` comments, which Xcode recognizes anywhere on a
+// line, and surrounding whitespace.
+func stripComment(line string) string {
+	if idx := strings.Index(line, "//"); idx >= 0 {
+		line = line[:idx]
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+}
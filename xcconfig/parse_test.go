@@ -0,0 +1,85 @@
+package xcconfig
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(`// Shared settings
+#include "Base.xcconfig"
+#include? "Local.xcconfig"
+
+PRODUCT_BUNDLE_IDENTIFIER = com.best.app // The bundle ID
+MARKETING_VERSION=1.2.0;
+OTHER_LDFLAGS = -ObjC
+OTHER_LDFLAGS = $(inherited) -lz
+SDKROOT[sdk=macosx*] = macosx
+VALID_ARCHS[sdk=iphoneos*][arch=arm64] = arm64
+`))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Include{
+		{Path: "Base.xcconfig"},
+		{Path: "Local.xcconfig", Optional: true},
+	}, f.Includes())
+
+	assignments := f.Assignments()
+	assert.Len(t, assignments, 6)
+	assert.Equal(t, Setting{Name: "MARKETING_VERSION", Conditions: []Condition{}, Value: "1.2.0"}, assignments[1])
+	assert.Equal(t, []Condition{SDK("iphoneos*"), Arch("arm64")}, assignments[5].Conditions)
+
+	assert.Equal(t, Settings{
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.best.app",
+		"MARKETING_VERSION":         "1.2.0",
+		"OTHER_LDFLAGS":             "-ObjC -lz",
+	}, f.Settings())
+
+	_, err = Parse(strings.NewReader("PRODUCT_NAME\n"))
+	assert.True(t, errors.Is(err, ErrInvalidSetting))
+	assert.Contains(t, err.Error(), "line 1")
+
+	_, err = Parse(strings.NewReader("1PRODUCT = name\n"))
+	assert.True(t, errors.Is(err, ErrInvalidSetting))
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xcconfig")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "Shared"), 0755))
+
+	files := map[string]string{
+		"Shared/Base.xcconfig": "PRODUCT_NAME = Best App\nOTHER_LDFLAGS = -ObjC\n",
+		"Debug.xcconfig":       "#include \"Shared/Base.xcconfig\"\n#include? \"Local.xcconfig\"\nOTHER_LDFLAGS = $(inherited) -lz\n",
+		"Cycle.xcconfig":       "#include \"Cycle.xcconfig\"\n",
+		"Missing.xcconfig":     "#include \"Shared/Missing.xcconfig\"\n",
+	}
+
+	for name, content := range files {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	f, err := Load(filepath.Join(dir, "Debug.xcconfig"))
+	assert.Nil(t, err)
+	assert.Equal(t, Settings{
+		"PRODUCT_NAME":  "Best App",
+		"OTHER_LDFLAGS": "-ObjC -lz",
+	}, f.Settings())
+
+	_, err = Load(filepath.Join(dir, "Cycle.xcconfig"))
+	assert.True(t, errors.Is(err, ErrIncludeCycle))
+
+	_, err = Load(filepath.Join(dir, "Missing.xcconfig"))
+	assert.NotNil(t, err)
+
+	_, err = Load(filepath.Join(dir, "Unknown.xcconfig"))
+	assert.NotNil(t, err)
+}
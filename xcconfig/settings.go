@@ -0,0 +1,185 @@
+// Package xcconfig provides a builder and parser for `.xcconfig` build
+// configuration files, including includes and conditional settings, and the
+// expansion of build setting references such as
+// `$(PRODUCT_BUNDLE_IDENTIFIER)`.
+package xcconfig
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// maxDepth limits the recursive expansion of build settings referencing
+// other build settings, which guards against reference cycles.
+const maxDepth = 32
+
+var (
+	rfc1034Regex = regexp.MustCompile(`[^A-Za-z0-9\-.]`)
+	c99Regex     = regexp.MustCompile(`[^A-Za-z0-9_]`)
+)
+
+// Settings maps build setting names to their values, i.e.
+// `PRODUCT_BUNDLE_IDENTIFIER` to `com.best.app`.
+// See https://developer.apple.com/documentation/xcode/build-settings-reference for more information.
+type Settings map[string]string
+
+// Expand expands the build setting references in the value, i.e. `$(VAR)`,
+// `${VAR}` and `$(VAR:rfc1034identifier)`. References to build settings that
+// are not defined, or that use an unknown modifier, are left in place and
+// returned as unresolved, i.e. `VAR` or `VAR:unknown`.
+//
+// The supported modifiers are `lower`, `upper`, `rfc1034identifier`,
+// `c99extidentifier`, `identifier`, `base`, `dir`, `file`, `suffix`,
+// `standardizepath` and `default=value`.
+func (s Settings) Expand(value string) (string, []string) {
+	unresolved := []string{}
+	expanded := s.expand(value, 0, &unresolved)
+
+	return expanded, unresolved
+}
+
+func (s Settings) expand(value string, depth int, unresolved *[]string) string {
+	buf := strings.Builder{}
+
+	for idx := 0; idx < len(value); idx++ {
+		open := byte(0)
+		if value[idx] == '$' && idx+1 < len(value) {
+			open = value[idx+1]
+		}
+
+		var closing byte
+		switch open {
+		case '(':
+			closing = ')'
+		case '{':
+			closing = '}'
+		default:
+			buf.WriteByte(value[idx])
+			continue
+		}
+
+		end := matchingClose(value, idx+2, open, closing)
+		if end < 0 {
+			buf.WriteString(value[idx:])
+			break
+		}
+
+		// Nested references in the name are expanded first
+		reference := s.expand(value[idx+2:end], depth, unresolved)
+
+		if expanded, ok := s.resolve(reference, depth, unresolved); ok {
+			buf.WriteString(expanded)
+		} else {
+			addUnique(unresolved, reference)
+			buf.WriteString(value[idx : end+1])
+		}
+
+		idx = end
+	}
+
+	return buf.String()
+}
+
+// matchingClose returns the index of the closing bracket matching an opening
+// bracket, allowing nested references such as `$(VAR_$(CONFIGURATION))`.
+func matchingClose(value string, start int, open, closing byte) int {
+	depth := 1
+	for idx := start; idx < len(value); idx++ {
+		switch value[idx] {
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return idx
+			}
+		}
+	}
+
+	return -1
+}
+
+// resolve returns the expanded value of a reference, i.e.
+// `PRODUCT_NAME:rfc1034identifier`. Build settings that are not defined are
+// treated as empty when the reference has a `default=value` modifier.
+// References within the value that cannot be resolved are left in place and
+// added to unresolved.
+func (s Settings) resolve(reference string, depth int, unresolved *[]string) (string, bool) {
+	parts := strings.Split(reference, ":")
+
+	raw, found := s[parts[0]]
+	if !found && !hasDefault(parts[1:]) || depth >= maxDepth {
+		return "", false
+	}
+
+	value := s.expand(raw, depth+1, unresolved)
+
+	for _, modifier := range parts[1:] {
+		var ok bool
+		if value, ok = applyModifier(value, modifier); !ok {
+			return "", false
+		}
+	}
+
+	return value, true
+}
+
+// hasDefault reports whether the modifiers include a `default=value`
+// modifier.
+func hasDefault(modifiers []string) bool {
+	for _, modifier := range modifiers {
+		if strings.HasPrefix(modifier, "default=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func applyModifier(value, modifier string) (string, bool) {
+	if strings.HasPrefix(modifier, "default=") {
+		if value == "" {
+			return strings.TrimPrefix(modifier, "default="), true
+		}
+		return value, true
+	}
+
+	switch modifier {
+	case "lower":
+		return strings.ToLower(value), true
+	case "upper":
+		return strings.ToUpper(value), true
+	case "rfc1034identifier":
+		return rfc1034Regex.ReplaceAllString(value, "-"), true
+	case "c99extidentifier", "identifier":
+		value = c99Regex.ReplaceAllString(value, "_")
+		if value != "" && value[0] >= '0' && value[0] <= '9' {
+			value = "_" + value
+		}
+		return value, true
+	case "base":
+		file := path.Base(value)
+		return strings.TrimSuffix(file, path.Ext(file)), true
+	case "dir":
+		return path.Dir(value), true
+	case "file":
+		return path.Base(value), true
+	case "suffix":
+		return path.Ext(value), true
+	case "standardizepath":
+		return path.Clean(value), true
+	default:
+		return "", false
+	}
+}
+
+func addUnique(values *[]string, value string) {
+	for _, v := range *values {
+		if v == value {
+			return
+		}
+	}
+
+	*values = append(*values, value)
+}
@@ -0,0 +1,57 @@
+package xcconfig
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSettings_Expand(t *testing.T) {
+	settings := Settings{
+		"PRODUCT_NAME":              "Best App",
+		"PRODUCT_BUNDLE_IDENTIFIER": "com.best.$(PRODUCT_NAME:rfc1034identifier:lower)",
+		"CONFIGURATION":             "Debug",
+		"API_URL_Debug":             "http://localhost",
+		"INFOPLIST_FILE":            "App/Support/Info.plist",
+		"EMPTY":                     "",
+		"CYCLE":                     "$(CYCLE)",
+	}
+
+	tests := []struct {
+		value      string
+		want       string
+		unresolved []string
+	}{
+		{"$(PRODUCT_BUNDLE_IDENTIFIER)", "com.best.best-app", []string{}},
+		{"${PRODUCT_NAME}.app", "Best App.app", []string{}},
+		{"$(PRODUCT_NAME:c99extidentifier)", "Best_App", []string{}},
+		{"$(PRODUCT_NAME:upper)", "BEST APP", []string{}},
+		{"$(API_URL_$(CONFIGURATION))", "http://localhost", []string{}},
+		{"$(INFOPLIST_FILE:dir)", "App/Support", []string{}},
+		{"$(INFOPLIST_FILE:file)", "Info.plist", []string{}},
+		{"$(INFOPLIST_FILE:base)", "Info", []string{}},
+		{"$(INFOPLIST_FILE:suffix)", ".plist", []string{}},
+		{"$(EMPTY:default=fallback)", "fallback", []string{}},
+		{"$(MISSING:default=fallback)", "fallback", []string{}},
+		{"$(MISSING:default=Best App:lower)", "best app", []string{}},
+		{"$(MARKETING_VERSION)", "$(MARKETING_VERSION)", []string{"MARKETING_VERSION"}},
+		{"$(PRODUCT_NAME:unknown)", "$(PRODUCT_NAME:unknown)", []string{"PRODUCT_NAME:unknown"}},
+		{"$(CYCLE)", "$(CYCLE)", []string{"CYCLE"}},
+		{"$(UNTERMINATED", "$(UNTERMINATED", []string{}},
+		{"Costs $5", "Costs $5", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, unresolved := settings.Expand(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.unresolved, unresolved)
+		})
+	}
+}
+
+func TestApplyModifier_Identifier(t *testing.T) {
+	got, ok := applyModifier("1st App", "identifier")
+	assert.True(t, ok)
+	assert.Equal(t, "_1st_App", got)
+}